@@ -0,0 +1,121 @@
+// Package assets serves the static CSS, JS, images and SDK files. Release
+// builds embed them in the binary; builds tagged "development" read them from
+// ./assets so edits show up without a rebuild.
+package assets
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// URLPrefix is where the static files are mounted.
+const URLPrefix = "/assets/"
+
+const immutableCacheControl = "public, max-age=31536000, immutable"
+
+type fingerprint struct {
+	hashed string // e.g. css/style.1a2b3c4d5e6f7a8b.css
+	etag   string
+}
+
+var (
+	indexOnce sync.Once
+	byName    map[string]fingerprint // logical name → fingerprint
+	byHashed  map[string]string      // hashed name → logical name
+)
+
+// FS returns the static file system.
+func FS() fs.FS {
+	return source()
+}
+
+// Path returns the public URL for a static file, e.g. Path("css/style.css").
+// In release builds the file name carries a content hash so it can be cached
+// forever; a changed file gets a new URL.
+func Path(name string) string {
+	if !fingerprinting {
+		return URLPrefix + name
+	}
+	buildIndex()
+	if fp, ok := byName[name]; ok {
+		return URLPrefix + fp.hashed
+	}
+	return URLPrefix + name
+}
+
+// Handler serves the static files. Mount it with the URLPrefix stripped.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+
+		immutable := false
+		if fingerprinting {
+			buildIndex()
+			if logical, ok := byHashed[name]; ok {
+				name = logical
+				immutable = true
+			}
+		}
+
+		data, err := fs.ReadFile(source(), name)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		if immutable {
+			w.Header().Set("Cache-Control", immutableCacheControl)
+		} else {
+			w.Header().Set("Cache-Control", "no-cache")
+		}
+		if fingerprinting {
+			w.Header().Set("ETag", byName[name].etag)
+		} else {
+			w.Header().Set("ETag", etagFor(data))
+		}
+
+		// ServeContent handles If-None-Match, ranges and Content-Type
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(data))
+	})
+}
+
+func buildIndex() {
+	indexOnce.Do(func() {
+		byName = map[string]fingerprint{}
+		byHashed = map[string]string{}
+
+		err := fs.WalkDir(source(), ".", func(name string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := fs.ReadFile(source(), name)
+			if err != nil {
+				return err
+			}
+			sum := sha256.Sum256(data)
+			short := hex.EncodeToString(sum[:8])
+
+			ext := path.Ext(name)
+			hashed := strings.TrimSuffix(name, ext) + "." + short + ext
+			byName[name] = fingerprint{hashed: hashed, etag: `"` + short + `"`}
+			byHashed[hashed] = name
+			return nil
+		})
+		if err != nil {
+			log.Println("Failed to fingerprint assets:", err)
+		}
+	})
+}
+
+func etagFor(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
@@ -0,0 +1,16 @@
+//go:build development
+
+package assets
+
+import (
+	"io/fs"
+	"os"
+)
+
+// Files change underneath us in development, so skip content hashing and let
+// the browser revalidate every request.
+const fingerprinting = false
+
+func source() fs.FS {
+	return os.DirFS("assets")
+}
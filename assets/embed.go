@@ -0,0 +1,20 @@
+//go:build !development
+
+package assets
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// css/ only holds a placeholder until `make scss` compiles the stylesheet
+// into it, so all: lets the pattern match in a fresh clone.
+//
+//go:embed all:css js images sdk
+var embedded embed.FS
+
+const fingerprinting = true
+
+func source() fs.FS {
+	return embedded
+}
@@ -1,57 +1,96 @@
 (function () {
   document.addEventListener("DOMContentLoaded", function () {
     // ---------- SCROLL ---------- //
-    const carousel = document.getElementById("carousel");
-    const scrollLeftBtn = document.getElementById("scrollLeft");
-    const scrollRightBtn = document.getElementById("scrollRight");
-
+    // The carousel and its arrows are re-rendered whenever HTMX swaps in new
+    // results (filters, shuffle, reset), so look them up on each click.
     const scrollAmount = 258; // Card width + gap
 
-    scrollLeftBtn.addEventListener("click", function () {
-      carousel.scrollBy({
-        left: -scrollAmount,
-        behavior: "smooth",
-      });
-    });
+    document.body.addEventListener("click", function (evt) {
+      const arrow = evt.target.closest("#scrollLeft, #scrollRight");
+      const carousel = document.getElementById("carousel");
+      if (!arrow || !carousel) return;
 
-    scrollRightBtn.addEventListener("click", function () {
       carousel.scrollBy({
-        left: scrollAmount,
+        left: arrow.id === "scrollLeft" ? -scrollAmount : scrollAmount,
         behavior: "smooth",
       });
     });
     // ---------- END SCROLL ---------- //
 
-    // ---------- SHUFFLE ---------- //
-    // 1. Grab container and original card nodes
-    const originalCards = Array.from(carousel.children);
+    // ---------- AUTOCOMPLETE ---------- //
+    // HTMX fetches suggestions into #mf-suggestions as the learner types;
+    // this adds listbox keyboard navigation and closes the list on choice.
+    const input = document.getElementById("mf-search");
+    const listbox = document.getElementById("mf-suggestions");
+    if (!input || !listbox) return;
 
-    // 2. Shuffle utility
-    function shuffleArray(arr) {
-      for (let i = arr.length - 1; i > 0; i--) {
-        const j = Math.floor(Math.random() * (i + 1));
-        [arr[i], arr[j]] = [arr[j], arr[i]];
+    const options = () => Array.from(listbox.querySelectorAll("[role=option]"));
+
+    const close = () => {
+      listbox.innerHTML = "";
+      input.setAttribute("aria-expanded", "false");
+      input.removeAttribute("aria-activedescendant");
+    };
+
+    const activate = (index) => {
+      const all = options();
+      all.forEach((el, i) => el.setAttribute("aria-selected", String(i === index)));
+      if (all[index]) {
+        input.setAttribute("aria-activedescendant", all[index].id);
+        all[index].scrollIntoView({ block: "nearest" });
+      } else {
+        input.removeAttribute("aria-activedescendant");
       }
-    }
-
-    // 3. Wire up Reset button
-    document
-      .querySelector(".footer__btn-left")
-      .addEventListener("click", () => {
-        // Clear & re‑append in original order
-        carousel.innerHTML = "";
-        originalCards.forEach((card) => carousel.appendChild(card));
-      });
+    };
 
-    // 4. Wire up Shuffle button
-    document
-      .querySelector(".footer__btn-right")
-      .addEventListener("click", () => {
-        const shuffled = originalCards.slice(); // copy
-        shuffleArray(shuffled);
-        carousel.innerHTML = "";
-        shuffled.forEach((card) => carousel.appendChild(card));
-      });
-    // ---------- END SHUFFLE ---------- //
+    listbox.addEventListener("htmx:afterSwap", function () {
+      input.setAttribute("aria-expanded", String(options().length > 0));
+      input.removeAttribute("aria-activedescendant");
+    });
+
+    input.addEventListener("keydown", function (evt) {
+      const all = options();
+      const current = all.findIndex((el) => el.getAttribute("aria-selected") === "true");
+      switch (evt.key) {
+        case "ArrowDown":
+        case "ArrowUp":
+          if (!all.length) return;
+          evt.preventDefault();
+          if (evt.key === "ArrowDown") {
+            activate((current + 1) % all.length);
+          } else {
+            activate(current <= 0 ? all.length - 1 : current - 1);
+          }
+          break;
+        case "Enter":
+          // Without an active option, Enter submits the form as a search
+          if (current < 0) {
+            close();
+            return;
+          }
+          evt.preventDefault();
+          all[current].click();
+          break;
+        case "Escape":
+          close();
+          break;
+      }
+    });
+
+    listbox.addEventListener("click", function (evt) {
+      const option = evt.target.closest("[role=option]");
+      if (!option) return;
+      if (option.classList.contains("search__option--subject")) {
+        // Subjects replace the search text; courses leave it as typed
+        input.value = option.querySelector(".search__text").textContent.trim();
+      }
+      setTimeout(close);
+    });
+
+    input.addEventListener("blur", function () {
+      // Let a click on an option land before the list goes away
+      setTimeout(close, 150);
+    });
+    // ---------- END AUTOCOMPLETE ---------- //
   });
 })();
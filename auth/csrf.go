@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/base64"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// CSRFCookie names the cookie holding the CSRF secret.
+	CSRFCookie = "mf_csrf"
+	// CSRFField is the form field carrying the token.
+	CSRFField = "csrf_token"
+	// CSRFHeader is the request header carrying the token, as HTMX sends it.
+	CSRFHeader = "X-CSRF-Token"
+)
+
+// csrfTokenKey caches the token on the gin context, so a cookie set earlier
+// in the request is reused rather than replaced.
+const csrfTokenKey = "auth.csrf_token"
+
+// CSRF issues and checks tokens for state-changing requests with the
+// double-submit pattern: a random secret goes in a signed, HttpOnly cookie
+// and the same secret is rendered into the page. A cross-site page can make
+// the browser send the cookie, but can't read the token to send with it.
+type CSRF struct {
+	cookies Cookies
+}
+
+// NewCSRF returns a CSRF keeping its secret in cookies.
+func NewCSRF(cookies Cookies) *CSRF {
+	return &CSRF{cookies: cookies}
+}
+
+// Token returns the request's token, setting the cookie if there isn't one
+// yet. The cookie lasts for the browser session.
+func (x *CSRF) Token(c *gin.Context) string {
+	if token := c.GetString(csrfTokenKey); token != "" {
+		return token
+	}
+	secret, ok := x.cookies.Get(c, CSRFCookie)
+	if !ok {
+		secret = make([]byte, 32)
+		_, _ = rand.Read(secret)
+		x.cookies.Set(c, CSRFCookie, secret, 0)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+	c.Set(csrfTokenKey, token)
+	return token
+}
+
+// Check reports whether the request sent back the token for its cookie, in
+// the CSRF header or form field.
+func (x *CSRF) Check(c *gin.Context) bool {
+	token := c.GetHeader(CSRFHeader)
+	if token == "" {
+		token = c.PostForm(CSRFField)
+	}
+	return x.Valid(c, token)
+}
+
+// Valid reports whether token matches the request's cookie.
+func (x *CSRF) Valid(c *gin.Context, token string) bool {
+	secret, ok := x.cookies.Get(c, CSRFCookie)
+	if !ok || token == "" {
+		return false
+	}
+	got, err := base64.RawURLEncoding.DecodeString(token)
+	return err == nil && hmac.Equal(got, secret)
+}
+
+type csrfContextKey struct{}
+
+// WithCSRFToken returns a copy of ctx carrying the request's CSRF token, for
+// templates to render into forms and HTMX headers.
+func WithCSRFToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, csrfContextKey{}, token)
+}
+
+// CSRFToken returns the CSRF token stored by WithCSRFToken, or "".
+func CSRFToken(ctx context.Context) string {
+	token, _ := ctx.Value(csrfContextKey{}).(string)
+	return token
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionCookie names the cookie holding the signed-in user.
+const SessionCookie = "mf_session"
+
+// Cookies sets and reads the service's signed cookies. The embed calls the
+// service cross-site, so outside development they are Secure and
+// SameSite=None.
+type Cookies struct {
+	Signer *Signer
+	Secure bool
+}
+
+// Set stores value in a signed cookie for maxAge.
+func (k Cookies) Set(c *gin.Context, name string, value []byte, maxAge time.Duration) {
+	http.SetCookie(c.Writer, k.cookie(name, k.Signer.Sign(name, value), int(maxAge.Seconds())))
+}
+
+// Get returns the value of a signed cookie, and false if it is missing or
+// fails verification.
+func (k Cookies) Get(c *gin.Context, name string) ([]byte, bool) {
+	raw, err := c.Cookie(name)
+	if err != nil || raw == "" {
+		return nil, false
+	}
+	return k.Signer.Verify(name, raw)
+}
+
+// Clear removes a cookie.
+func (k Cookies) Clear(c *gin.Context, name string) {
+	http.SetCookie(c.Writer, k.cookie(name, "", -1))
+}
+
+func (k Cookies) cookie(name, value string, maxAge int) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   k.Secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if k.Secure {
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	return cookie
+}
+
+type session struct {
+	User    string `json:"sub"`
+	Expires int64  `json:"exp"`
+}
+
+// Sessions remembers signed-in users in a signed cookie.
+type Sessions struct {
+	cookies Cookies
+	ttl     time.Duration
+}
+
+// NewSessions returns Sessions lasting ttl.
+func NewSessions(cookies Cookies, ttl time.Duration) *Sessions {
+	return &Sessions{cookies: cookies, ttl: ttl}
+}
+
+// Start signs user in for the session's lifetime.
+func (s *Sessions) Start(c *gin.Context, user string) {
+	value, _ := json.Marshal(session{User: user, Expires: time.Now().Add(s.ttl).Unix()})
+	s.cookies.Set(c, SessionCookie, value, s.ttl)
+}
+
+// User returns the signed-in user, if any.
+func (s *Sessions) User(c *gin.Context) (string, bool) {
+	value, ok := s.cookies.Get(c, SessionCookie)
+	if !ok {
+		return "", false
+	}
+	var sess session
+	if json.Unmarshal(value, &sess) != nil || sess.User == "" || time.Now().Unix() >= sess.Expires {
+		return "", false
+	}
+	return sess.User, true
+}
+
+// End signs the user out.
+func (s *Sessions) End(c *gin.Context) {
+	s.cookies.Clear(c, SessionCookie)
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSigner(t *testing.T) {
+	s := NewSigner([]byte("key"))
+	signed := s.Sign("mf_session", []byte(`{"sub":"user-1"}`))
+	value, sig, _ := strings.Cut(signed, ".")
+
+	tests := []struct {
+		name   string
+		cookie string
+		signed string
+		want   []byte
+		wantOK bool
+	}{
+		{name: "round trip", cookie: "mf_session", signed: signed, want: []byte(`{"sub":"user-1"}`), wantOK: true},
+		{name: "other name", cookie: "mf_saved", signed: signed},
+		{name: "other key", cookie: "mf_session", signed: NewSigner([]byte("other")).Sign("mf_session", []byte(`{"sub":"user-1"}`))},
+		{name: "tampered value", cookie: "mf_session", signed: s.Sign("mf_session", []byte(`{"sub":"user-2"}`))[:len(value)] + "." + sig},
+		{name: "tampered signature", cookie: "mf_session", signed: value + "." + sig[:len(sig)-2] + "AA"},
+		{name: "no signature", cookie: "mf_session", signed: value},
+		{name: "bad encoding", cookie: "mf_session", signed: "!!!." + sig},
+		{name: "empty", cookie: "mf_session", signed: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := s.Verify(tt.cookie, tt.signed)
+			if ok != tt.wantOK || !bytes.Equal(got, tt.want) {
+				t.Errorf("Verify() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSignerEmptyValue(t *testing.T) {
+	s := NewSigner([]byte("key"))
+	got, ok := s.Verify("csrf", s.Sign("csrf", nil))
+	if !ok || len(got) != 0 {
+		t.Errorf("Verify(Sign(nil)) = %q, %v, want empty, true", got, ok)
+	}
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformedToken = errors.New("auth: malformed token")
+	ErrBadSignature   = errors.New("auth: invalid token signature")
+	ErrExpiredToken   = errors.New("auth: token expired")
+	ErrNoExpiry       = errors.New("auth: token has no expiry")
+	ErrNoSubject      = errors.New("auth: token has no subject")
+)
+
+// Claims are the parts of a sign-in token the service uses.
+type Claims struct {
+	Subject string
+	Email   string
+	Expires time.Time
+}
+
+// VerifyToken checks an HS256 JWT, such as a Supabase access token, against
+// secret and returns its claims. The token must carry an expiry, be
+// unexpired at now and name a subject.
+func VerifyToken(token string, secret []byte, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrMalformedToken
+	}
+	enc := base64.RawURLEncoding
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return Claims{}, ErrMalformedToken
+	}
+
+	sig, err := enc.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	m := hmac.New(sha256.New, secret)
+	m.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, m.Sum(nil)) {
+		return Claims{}, ErrBadSignature
+	}
+
+	var payload struct {
+		Sub   string `json:"sub"`
+		Email string `json:"email"`
+		Exp   int64  `json:"exp"`
+	}
+	if err := decodeSegment(parts[1], &payload); err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	if payload.Exp == 0 {
+		return Claims{}, ErrNoExpiry
+	}
+	claims := Claims{Subject: payload.Sub, Email: payload.Email, Expires: time.Unix(payload.Exp, 0)}
+	if !now.Before(claims.Expires) {
+		return Claims{}, ErrExpiredToken
+	}
+	if claims.Subject == "" {
+		return Claims{}, ErrNoSubject
+	}
+	return claims, nil
+}
+
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("test-secret")
+
+// signToken builds an HS256 JWT from raw header and payload JSON.
+func signToken(header, payload string, secret []byte) string {
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(payload))
+	m := hmac.New(sha256.New, secret)
+	m.Write([]byte(unsigned))
+	return unsigned + "." + enc.EncodeToString(m.Sum(nil))
+}
+
+func TestVerifyToken(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	hs256 := `{"alg":"HS256","typ":"JWT"}`
+
+	tests := []struct {
+		name    string
+		token   string
+		want    Claims
+		wantErr error
+	}{
+		{
+			name:  "valid",
+			token: signToken(hs256, `{"sub":"user-1","email":"a@example.com","exp":1700000060}`, testSecret),
+			want:  Claims{Subject: "user-1", Email: "a@example.com", Expires: time.Unix(1_700_000_060, 0)},
+		},
+		{
+			name:    "no expiry",
+			token:   signToken(hs256, `{"sub":"user-1"}`, testSecret),
+			wantErr: ErrNoExpiry,
+		},
+		{
+			name:    "zero expiry",
+			token:   signToken(hs256, `{"sub":"user-1","exp":0}`, testSecret),
+			wantErr: ErrNoExpiry,
+		},
+		{
+			name:    "expired",
+			token:   signToken(hs256, `{"sub":"user-1","exp":1699999999}`, testSecret),
+			wantErr: ErrExpiredToken,
+		},
+		{
+			name:    "expires now",
+			token:   signToken(hs256, `{"sub":"user-1","exp":1700000000}`, testSecret),
+			wantErr: ErrExpiredToken,
+		},
+		{
+			name:    "no subject",
+			token:   signToken(hs256, `{"exp":1700000060}`, testSecret),
+			wantErr: ErrNoSubject,
+		},
+		{
+			name:    "wrong secret",
+			token:   signToken(hs256, `{"sub":"user-1","exp":1700000060}`, []byte("other")),
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "other algorithm",
+			token:   signToken(`{"alg":"none"}`, `{"sub":"user-1","exp":1700000060}`, testSecret),
+			wantErr: ErrMalformedToken,
+		},
+		{
+			name:    "two segments",
+			token:   "e30.e30",
+			wantErr: ErrMalformedToken,
+		},
+		{
+			name:    "bad signature encoding",
+			token:   signToken(hs256, `{"sub":"user-1","exp":1700000060}`, testSecret) + "!",
+			wantErr: ErrMalformedToken,
+		},
+		{
+			name:    "payload not JSON",
+			token:   signToken(hs256, `not json`, testSecret),
+			wantErr: ErrMalformedToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifyToken(tt.token, testSecret, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyToken() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.Subject != tt.want.Subject || got.Email != tt.want.Email || !got.Expires.Equal(tt.want.Expires) {
+				t.Errorf("VerifyToken() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
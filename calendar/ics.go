@@ -0,0 +1,90 @@
+// Package calendar writes iCalendar (RFC 5545) files for course intakes.
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ProdID identifies this service as the producer of the calendar.
+const ProdID = "-//go-ms-kit//Course Intakes//EN"
+
+// Event is an all-day calendar entry. End is the last day of the event; when
+// zero the event covers Start only.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+}
+
+// Write renders events as a VCALENDAR to w.
+func Write(w io.Writer, events []Event, now time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", ProdID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	for _, e := range events {
+		end := e.End
+		if end.IsZero() || end.Before(e.Start) {
+			end = e.Start
+		}
+
+		line("BEGIN", "VEVENT")
+		line("UID", e.UID)
+		line("DTSTAMP", now.UTC().Format("20060102T150405Z"))
+		// DTEND is exclusive for all-day events, so it is the day after
+		line("DTSTART;VALUE=DATE", e.Start.Format("20060102"))
+		line("DTEND;VALUE=DATE", end.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escape(e.Description))
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	return bw.Flush()
+}
+
+// escape applies the TEXT value escaping from RFC 5545 section 3.3.11.
+var escape = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+).Replace
+
+// writeFolded writes a content line, folding it at 75 octets without splitting
+// a UTF-8 sequence, and terminates it with CRLF.
+func writeFolded(w *bufio.Writer, s string) {
+	const limit = 75
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !startsRune(s[cut]) {
+			cut--
+		}
+		fmt.Fprint(w, s[:cut], "\r\n ")
+		s = s[cut:]
+		// Continuation lines lose one octet to the leading space
+		width = limit - 1
+	}
+	fmt.Fprint(w, s, "\r\n")
+}
+
+func startsRune(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,132 @@
+package calendar
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{name: "plain", in: "Diploma of Nursing", want: "Diploma of Nursing"},
+		{name: "separators", in: "Lead; manage, deliver", want: `Lead\; manage\, deliver`},
+		{name: "backslash", in: `C:\path`, want: `C:\\path`},
+		{name: "newlines", in: "one\r\ntwo\nthree", want: `one\ntwo\nthree`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escape(tt.in); got != tt.want {
+				t.Errorf("escape(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		lines int
+	}{
+		{name: "short", in: "SUMMARY:Intake", lines: 1},
+		{name: "exactly the limit", in: strings.Repeat("a", 75), lines: 1},
+		{name: "one over", in: strings.Repeat("a", 76), lines: 2},
+		{name: "long", in: strings.Repeat("a", 75+74+1), lines: 3},
+		{name: "multibyte not split", in: strings.Repeat("é", 80), lines: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			w := bufio.NewWriter(&b)
+			writeFolded(w, tt.in)
+			w.Flush()
+
+			out := b.String()
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("output %q doesn't end with CRLF", out)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			if len(lines) != tt.lines {
+				t.Errorf("got %d lines, want %d: %q", len(lines), tt.lines, lines)
+			}
+			var unfolded strings.Builder
+			for i, line := range lines {
+				if len(line) > 75 {
+					t.Errorf("line %d is %d octets", i, len(line))
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a UTF-8 sequence: %q", i, line)
+				}
+				if i > 0 {
+					line = strings.TrimPrefix(line, " ")
+				}
+				unfolded.WriteString(line)
+			}
+			if unfolded.String() != tt.in {
+				t.Errorf("unfolded = %q, want %q", unfolded.String(), tt.in)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	start := time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 1, 5, 9, 30, 0, 0, time.FixedZone("AEDT", 11*60*60))
+
+	tests := []struct {
+		name  string
+		event Event
+		want  []string
+	}{
+		{
+			name:  "single day",
+			event: Event{UID: "a@x", Summary: "Intake", Start: start},
+			want:  []string{"DTSTART;VALUE=DATE:20260209", "DTEND;VALUE=DATE:20260210", "SUMMARY:Intake"},
+		},
+		{
+			name:  "range end is exclusive",
+			event: Event{UID: "a@x", Summary: "Intake", Start: start, End: start.AddDate(0, 0, 4)},
+			want:  []string{"DTEND;VALUE=DATE:20260214"},
+		},
+		{
+			name:  "end before start",
+			event: Event{UID: "a@x", Summary: "Intake", Start: start, End: start.AddDate(0, 0, -1)},
+			want:  []string{"DTEND;VALUE=DATE:20260210"},
+		},
+		{
+			name:  "stamp in UTC",
+			event: Event{UID: "a@x", Summary: "Intake", Start: start},
+			want:  []string{"DTSTAMP:20260104T223000Z"},
+		},
+		{
+			name:  "description escaped",
+			event: Event{UID: "a@x", Summary: "Intake", Description: "Online, part time", Start: start},
+			want:  []string{`DESCRIPTION:Online\, part time`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := Write(&b, []Event{tt.event}, now); err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(b.String(), "\r\n")
+			if lines[0] != "BEGIN:VCALENDAR" || lines[len(lines)-2] != "END:VCALENDAR" {
+				t.Errorf("calendar not wrapped in VCALENDAR: %q", lines)
+			}
+			for _, want := range tt.want {
+				found := false
+				for _, line := range lines {
+					found = found || line == want
+				}
+				if !found {
+					t.Errorf("missing line %q in %q", want, lines)
+				}
+			}
+		})
+	}
+}
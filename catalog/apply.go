@@ -0,0 +1,74 @@
+package catalog
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// List is a rendered view of the course list: the courses shown, the facets
+// counted over them and the query that produced them.
+type List struct {
+	Courses []graph.CourseView
+	Facets  []Facet
+	Query   Query
+}
+
+// NewList builds the view for courses not yet narrowed by q's facet filter.
+// It applies the filter itself, after counting the facets over the wider
+// list.
+func NewList(courses []graph.CourseView, q Query, locale language.Tag, now time.Time) List {
+	courses = Apply(courses, q, locale, now)
+	return List{Courses: q.Filter.Apply(courses), Facets: Facets(courses, q), Query: q}
+}
+
+// Apply filters courses to q's start date window and orders them. The facet
+// filter is left to NewList, which needs the courses outside it to count
+// the facets. Course names are compared using the collation rules of locale.
+// The input slice is not modified, since it usually comes from the cache.
+func Apply(courses []graph.CourseView, q Query, locale language.Tag, now time.Time) []graph.CourseView {
+	if !q.StartsFrom.IsZero() || !q.StartsTo.IsZero() {
+		courses = graph.StartingBetween(courses, q.StartsFrom, q.StartsTo, now)
+	}
+
+	switch q.Sort {
+	case Name:
+		col := collate.New(locale, collate.IgnoreCase)
+		courses = slices.Clone(courses)
+		slices.SortStableFunc(courses, func(a, b graph.CourseView) int {
+			return col.CompareString(a.CourseName, b.CourseName)
+		})
+	case Start:
+		courses = graph.SortByNextIntake(courses, now)
+	case Duration:
+		courses = slices.Clone(courses)
+		slices.SortStableFunc(courses, compareDuration)
+	case Random:
+		courses = slices.Clone(courses)
+		r := rand.New(rand.NewPCG(q.Seed, q.Seed>>32|1))
+		r.Shuffle(len(courses), func(i, j int) {
+			courses[i], courses[j] = courses[j], courses[i]
+		})
+	}
+	return courses
+}
+
+// compareDuration orders shortest first, with unknown lengths last.
+func compareDuration(a, b graph.CourseView) int {
+	av, bv := a.Duration.Valid(), b.Duration.Valid()
+	switch {
+	case av && bv:
+		return cmp.Compare(a.Duration.Approx(), b.Duration.Approx())
+	case av:
+		return -1
+	case bv:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,100 @@
+package catalog
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+
+	"golang.org/x/text/language"
+)
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func listed(id int, name string, duration models.Duration, starts ...time.Time) graph.CourseView {
+	c := graph.CourseView{Course: models.Course{ID: id, CourseName: name}, Duration: duration}
+	for _, s := range starts {
+		c.Intakes = append(c.Intakes, models.Intake{Start: s})
+	}
+	return c
+}
+
+func ids(courses []graph.CourseView) []int {
+	var out []int
+	for _, c := range courses {
+		out = append(out, c.ID)
+	}
+	return out
+}
+
+func TestApply(t *testing.T) {
+	now := day(time.March, 1)
+	courses := []graph.CourseView{
+		listed(1, "zoology", models.Duration{Length: 2, Unit: models.Year}, day(time.February, 1), day(time.July, 1)),
+		listed(2, "Écologie", models.Duration{}, day(time.April, 1)),
+		listed(3, "Accounting", models.Duration{Length: 6, Unit: models.Month}),
+		listed(4, "ecology", models.Duration{Length: 40, Unit: models.Hour}, day(time.March, 1)),
+		listed(5, "Business", models.Duration{Length: 12, Unit: models.Week}, day(time.May, 1)),
+	}
+
+	tests := []struct {
+		name   string
+		query  Query
+		locale language.Tag
+		want   []int
+	}{
+		{name: "relevance keeps the order", query: Query{Sort: Relevance}, want: []int{1, 2, 3, 4, 5}},
+		{name: "name, ignoring case and accents", query: Query{Sort: Name}, locale: language.French, want: []int{3, 5, 2, 4, 1}},
+		{name: "next start, none last", query: Query{Sort: Start}, want: []int{4, 2, 5, 1, 3}},
+		{name: "duration, unknown last", query: Query{Sort: Duration}, want: []int{4, 5, 3, 1, 2}},
+		{name: "starting from", query: Query{StartsFrom: day(time.April, 15)}, want: []int{1, 5}},
+		{name: "starting by", query: Query{StartsTo: day(time.April, 1)}, want: []int{2, 4}},
+		{name: "past intakes don't count", query: Query{StartsTo: day(time.February, 28)}},
+		{
+			name:  "window and order",
+			query: Query{Sort: Start, StartsFrom: day(time.March, 1), StartsTo: day(time.May, 1)},
+			want:  []int{4, 2, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := ids(courses)
+			got := ids(Apply(courses, tt.query, tt.locale, now))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Apply() = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(ids(courses), before) {
+				t.Errorf("Apply() reordered its input to %v", ids(courses))
+			}
+		})
+	}
+}
+
+func TestApplyShuffle(t *testing.T) {
+	var courses []graph.CourseView
+	for i := 1; i <= 20; i++ {
+		courses = append(courses, listed(i, "", models.Duration{}))
+	}
+	shuffle := func(seed uint64) []int {
+		return ids(Apply(courses, Query{Sort: Random, Seed: seed}, language.English, time.Time{}))
+	}
+
+	first := shuffle(42)
+	if !slices.Equal(shuffle(42), first) {
+		t.Error("the same seed gave a different order")
+	}
+	if slices.Equal(shuffle(43), first) {
+		t.Error("another seed gave the same order")
+	}
+	if slices.Equal(first, ids(courses)) {
+		t.Error("shuffle kept the original order")
+	}
+	sorted := slices.Sorted(slices.Values(first))
+	if !slices.Equal(sorted, ids(courses)) {
+		t.Errorf("shuffle lost or duplicated courses: %v", first)
+	}
+}
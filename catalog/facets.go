@@ -0,0 +1,103 @@
+package catalog
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+)
+
+// facet describes a filterable course field and its URL param.
+type facet struct {
+	Param string
+	// Category is the i18n value category used to label the values, if any.
+	Category string
+	values   func(*graph.CourseFilter) *[]string
+	course   func(graph.CourseView) []string
+}
+
+var facets = []facet{
+	{
+		Param:  "level",
+		values: func(f *graph.CourseFilter) *[]string { return &f.Level },
+		course: func(c graph.CourseView) []string { return c.Level },
+	},
+	{
+		Param:    "delivery",
+		Category: "delivery",
+		values:   func(f *graph.CourseFilter) *[]string { return &f.Delivery },
+		course:   func(c graph.CourseView) []string { return c.Delivery },
+	},
+	{
+		Param:  "location",
+		values: func(f *graph.CourseFilter) *[]string { return &f.Locations },
+		course: func(c graph.CourseView) []string { return c.Locations },
+	},
+	{
+		Param:    "frequency",
+		Category: "frequency",
+		values:   func(f *graph.CourseFilter) *[]string { return &f.Frequency },
+		course:   func(c graph.CourseView) []string { return c.Frequency },
+	},
+}
+
+// Facet is a filter group for the filter bar.
+type Facet struct {
+	Param    string
+	Category string
+	Values   []FacetValue
+}
+
+// FacetValue is one option of a facet, with the number of courses in the
+// current result set that have it.
+type FacetValue struct {
+	Value    string
+	Count    int
+	Selected bool
+}
+
+// Facets counts the values of each facet across courses, the list before q's
+// facet filter. A facet is counted over the courses passing every other
+// facet's selection but not its own, since values of one facet are OR'd:
+// each count is what picking that value would show. Selected values are
+// always listed so they can be cleared, even when no course has them.
+// Values are ordered by count, then name.
+func Facets(courses []graph.CourseView, q Query) []Facet {
+	var result []Facet
+	for _, f := range facets {
+		others := q.Filter
+		*f.values(&others) = nil
+
+		counts := map[string]int{}
+		for _, course := range courses {
+			if !others.Matches(course) {
+				continue
+			}
+			for _, v := range nonEmpty(f.course(course)) {
+				counts[v]++
+			}
+		}
+		selected := *f.values(&q.Filter)
+		for _, v := range selected {
+			if _, ok := counts[v]; !ok {
+				counts[v] = 0
+			}
+		}
+		if len(counts) == 0 {
+			continue
+		}
+
+		group := Facet{Param: f.Param, Category: f.Category}
+		for v, n := range counts {
+			group.Values = append(group.Values, FacetValue{Value: v, Count: n, Selected: slices.Contains(selected, v)})
+		}
+		slices.SortFunc(group.Values, func(a, b FacetValue) int {
+			if c := cmp.Compare(b.Count, a.Count); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Value, b.Value)
+		})
+		result = append(result, group)
+	}
+	return result
+}
@@ -0,0 +1,157 @@
+package catalog
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+
+	"golang.org/x/text/language"
+)
+
+func faceted(id int, level, delivery []string) graph.CourseView {
+	return graph.CourseView{Course: models.Course{ID: id, Level: level, Delivery: delivery}}
+}
+
+// counts flattens facets to param → "value=count" in order, with a * for
+// selected values.
+func counts(facets []Facet) map[string][]string {
+	out := map[string][]string{}
+	for _, f := range facets {
+		for _, v := range f.Values {
+			s := v.Value + "=" + strconv.Itoa(v.Count)
+			if v.Selected {
+				s += "*"
+			}
+			out[f.Param] = append(out[f.Param], s)
+		}
+	}
+	return out
+}
+
+func TestFacets(t *testing.T) {
+	courses := []graph.CourseView{
+		faceted(1, []string{"Diploma"}, []string{"ONLINE"}),
+		faceted(2, []string{"Diploma"}, []string{"ONLINE", "CLASSROOM"}),
+		faceted(3, []string{"Certificate IV"}, []string{"CLASSROOM"}),
+		faceted(4, []string{"Certificate IV"}, []string{"ONLINE", "ONLINE", ""}),
+		faceted(5, []string{"Advanced Diploma"}, nil),
+	}
+
+	tests := []struct {
+		name   string
+		filter graph.CourseFilter
+		want   map[string][]string
+	}{
+		{
+			name: "nothing selected, by count then name",
+			want: map[string][]string{
+				"level":    {"Certificate IV=2", "Diploma=2", "Advanced Diploma=1"},
+				"delivery": {"ONLINE=3", "CLASSROOM=2"},
+			},
+		},
+		{
+			name:   "a facet's own selection doesn't narrow it",
+			filter: graph.CourseFilter{Level: []string{"Diploma"}},
+			want: map[string][]string{
+				"level":    {"Certificate IV=2", "Diploma=2*", "Advanced Diploma=1"},
+				"delivery": {"ONLINE=2", "CLASSROOM=1"},
+			},
+		},
+		{
+			name:   "selections across facets",
+			filter: graph.CourseFilter{Level: []string{"Diploma", "Certificate IV"}, Delivery: []string{"CLASSROOM"}},
+			want: map[string][]string{
+				"level":    {"Certificate IV=1*", "Diploma=1*"},
+				"delivery": {"ONLINE=3", "CLASSROOM=2*"},
+			},
+		},
+		{
+			name:   "selected value no course has",
+			filter: graph.CourseFilter{Delivery: []string{"WORKPLACE"}},
+			want: map[string][]string{
+				"delivery": {"ONLINE=3", "CLASSROOM=2", "WORKPLACE=0*"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := counts(Facets(courses, Query{Filter: tt.filter}))
+			for _, param := range []string{"level", "delivery", "location", "frequency"} {
+				if !slices.Equal(got[param], tt.want[param]) {
+					t.Errorf("Facets() %s = %q, want %q", param, got[param], tt.want[param])
+				}
+			}
+		})
+	}
+}
+
+func TestNewList(t *testing.T) {
+	courses := []graph.CourseView{
+		faceted(1, []string{"Diploma"}, []string{"ONLINE"}),
+		faceted(2, []string{"Certificate IV"}, []string{"ONLINE"}),
+		faceted(3, []string{"Diploma"}, []string{"CLASSROOM"}),
+	}
+	q := Query{Filter: graph.CourseFilter{Level: []string{"Diploma"}, Delivery: []string{"ONLINE"}}}
+	list := NewList(courses, q, language.English, time.Time{})
+
+	if got := ids(list.Courses); !slices.Equal(got, []int{1}) {
+		t.Errorf("Courses = %v, want [1]", got)
+	}
+	// Counted over the list before the filter
+	want := map[string][]string{
+		"level":    {"Certificate IV=1", "Diploma=1*"},
+		"delivery": {"CLASSROOM=1", "ONLINE=1*"},
+	}
+	if got := counts(list.Facets); !slices.Equal(got["level"], want["level"]) || !slices.Equal(got["delivery"], want["delivery"]) {
+		t.Errorf("Facets = %q, want %q", got, want)
+	}
+}
+
+func TestQueryFacetParams(t *testing.T) {
+	values, _ := url.ParseQuery("level=Diploma&level=&level=Diploma&delivery=ONLINE&location=Sydney&frequency=FULL_TIME&frequency=PART_TIME")
+	q := ParseQuery(values, "")
+
+	want := graph.CourseFilter{
+		Level:     []string{"Diploma"},
+		Delivery:  []string{"ONLINE"},
+		Locations: []string{"Sydney"},
+		Frequency: []string{"FULL_TIME", "PART_TIME"},
+	}
+	if !slices.Equal(q.Filter.Level, want.Level) || !slices.Equal(q.Filter.Delivery, want.Delivery) ||
+		!slices.Equal(q.Filter.Locations, want.Locations) || !slices.Equal(q.Filter.Frequency, want.Frequency) {
+		t.Errorf("ParseQuery() filter = %+v, want %+v", q.Filter, want)
+	}
+	if got := q.URL("/courses"); got != "/courses?delivery=ONLINE&frequency=FULL_TIME&frequency=PART_TIME&level=Diploma&location=Sydney" {
+		t.Errorf("URL() = %q", got)
+	}
+}
+
+func TestQueryToggle(t *testing.T) {
+	q := Query{Filter: graph.CourseFilter{Level: []string{"Diploma", "Certificate IV"}}}
+
+	added := q.Toggle("level", "Advanced Diploma")
+	removed := q.Toggle("level", "Diploma")
+	unknown := q.Toggle("colour", "red")
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"added", added.Filter.Level, []string{"Diploma", "Certificate IV", "Advanced Diploma"}},
+		{"removed", removed.Filter.Level, []string{"Certificate IV"}},
+		{"unknown facet", unknown.Filter.Level, []string{"Diploma", "Certificate IV"}},
+		{"receiver untouched", q.Filter.Level, []string{"Diploma", "Certificate IV"}},
+		{"cleared", q.ClearFilters().Filter.Level, nil},
+	}
+	for _, tt := range tests {
+		if !slices.Equal(tt.got, tt.want) {
+			t.Errorf("%s: Level = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Package catalog applies list options (facet filters, ordering, start date
+// window) to the course list and round-trips them through URL query
+// parameters, so every view of the list can be shared as a link.
+package catalog
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+)
+
+// Order is how the course list is sorted.
+type Order string
+
+const (
+	// Relevance keeps the backend's order, or the search ranking.
+	Relevance Order = "relevance"
+	Name      Order = "name"
+	// Start sorts by next upcoming intake.
+	Start    Order = "start"
+	Duration Order = "duration"
+	// Random shuffles the list reproducibly from Query.Seed.
+	Random Order = "random"
+)
+
+// orders are the accepted values of the sort param.
+var orders = []Order{Relevance, Name, Start, Duration, Random}
+
+const dateLayout = "2006-01-02"
+
+// Query is the state of a course list view.
+type Query struct {
+	// Text is a full-text search; when set, relevance is the search score.
+	Text       string
+	Tag        string
+	Filter     graph.CourseFilter
+	Sort       Order
+	Seed       uint64
+	StartsFrom time.Time
+	StartsTo   time.Time
+}
+
+// ParseQuery reads list options from URL params. Unknown sort orders and
+// malformed dates are ignored rather than rejected, since they usually come
+// from hand-edited links. A random order without a seed gets a fresh one.
+func ParseQuery(values url.Values, defaultTag string) Query {
+	q := Query{Text: values.Get("q"), Tag: values.Get("tag"), Sort: Relevance}
+	if q.Text == "" {
+		// The SDK passes the page's keyword as the search
+		q.Text = values.Get("keyword")
+	}
+	if q.Tag == "" {
+		q.Tag = defaultTag
+	}
+
+	for _, o := range orders {
+		if Order(values.Get("sort")) == o {
+			q.Sort = o
+		}
+	}
+	if q.Sort == Random {
+		seed, err := strconv.ParseUint(values.Get("seed"), 10, 64)
+		if err != nil {
+			seed = uint64(time.Now().UnixNano())
+		}
+		q.Seed = seed
+	}
+
+	for _, f := range facets {
+		*f.values(&q.Filter) = nonEmpty(values[f.Param])
+	}
+
+	q.StartsFrom, _ = time.Parse(dateLayout, values.Get("starts_from"))
+	q.StartsTo, _ = time.Parse(dateLayout, values.Get("starts_to"))
+	return q
+}
+
+// Values encodes the query as URL params, leaving out defaults.
+func (q Query) Values() url.Values {
+	v := url.Values{}
+	if q.Text != "" {
+		v.Set("q", q.Text)
+	}
+	if q.Tag != "" {
+		v.Set("tag", q.Tag)
+	}
+	for _, f := range facets {
+		for _, value := range *f.values(&q.Filter) {
+			v.Add(f.Param, value)
+		}
+	}
+	if q.Sort != "" && q.Sort != Relevance {
+		v.Set("sort", string(q.Sort))
+	}
+	if q.Sort == Random && q.Seed != 0 {
+		v.Set("seed", strconv.FormatUint(q.Seed, 10))
+	}
+	if !q.StartsFrom.IsZero() {
+		v.Set("starts_from", q.StartsFrom.Format(dateLayout))
+	}
+	if !q.StartsTo.IsZero() {
+		v.Set("starts_to", q.StartsTo.Format(dateLayout))
+	}
+	return v
+}
+
+// Field is a single URL param of a query.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Fields lists the query's URL params in a stable order, for rendering the
+// list state as form inputs.
+func (q Query) Fields() []Field {
+	values := q.Values()
+	var fields []Field
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		for _, value := range values[name] {
+			fields = append(fields, Field{Name: name, Value: value})
+		}
+	}
+	return fields
+}
+
+// URL returns path with the query's params.
+func (q Query) URL(path string) string {
+	if v := q.Values().Encode(); v != "" {
+		return path + "?" + v
+	}
+	return path
+}
+
+// Toggle returns the query with value added to or removed from a facet.
+func (q Query) Toggle(param, value string) Query {
+	for _, f := range facets {
+		if f.Param != param {
+			continue
+		}
+		// Copy the filter so the receiver's slices are never shared
+		q.Filter = cloneFilter(q.Filter)
+		values := f.values(&q.Filter)
+		if i := slices.Index(*values, value); i >= 0 {
+			*values = slices.Delete(*values, i, i+1)
+		} else {
+			*values = append(*values, value)
+		}
+	}
+	return q
+}
+
+// ClearFilters returns the query without facet filters.
+func (q Query) ClearFilters() Query {
+	q.Filter = graph.CourseFilter{}
+	return q
+}
+
+func cloneFilter(f graph.CourseFilter) graph.CourseFilter {
+	return graph.CourseFilter{
+		Level:     slices.Clone(f.Level),
+		Delivery:  slices.Clone(f.Delivery),
+		Locations: slices.Clone(f.Locations),
+		Frequency: slices.Clone(f.Frequency),
+	}
+}
+
+func nonEmpty(values []string) []string {
+	var kept []string
+	for _, v := range values {
+		if v != "" && !slices.Contains(kept, v) {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
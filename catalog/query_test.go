@@ -0,0 +1,106 @@
+package catalog
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+		want   Query
+	}{
+		{name: "defaults", params: "", want: Query{Tag: "business", Sort: Relevance}},
+		{name: "tag", params: "tag=health", want: Query{Tag: "health", Sort: Relevance}},
+		{name: "search", params: "q=lead", want: Query{Text: "lead", Tag: "business", Sort: Relevance}},
+		{name: "keyword as search", params: "keyword=lead", want: Query{Text: "lead", Tag: "business", Sort: Relevance}},
+		{name: "q over keyword", params: "q=lead&keyword=nurse", want: Query{Text: "lead", Tag: "business", Sort: Relevance}},
+		{name: "sort", params: "sort=duration", want: Query{Tag: "business", Sort: Duration}},
+		{name: "unknown sort", params: "sort=price", want: Query{Tag: "business", Sort: Relevance}},
+		{name: "seeded shuffle", params: "sort=random&seed=42", want: Query{Tag: "business", Sort: Random, Seed: 42}},
+		{name: "seed without shuffle", params: "seed=42", want: Query{Tag: "business", Sort: Relevance}},
+		{
+			name:   "start window",
+			params: "starts_from=2025-03-01&starts_to=2025-06-30",
+			want: Query{
+				Tag:        "business",
+				Sort:       Relevance,
+				StartsFrom: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+				StartsTo:   time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{name: "malformed dates", params: "starts_from=March&starts_to=2025-13-01", want: Query{Tag: "business", Sort: Relevance}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.params)
+			got := ParseQuery(values, "business")
+			if got.Text != tt.want.Text || got.Tag != tt.want.Tag || got.Sort != tt.want.Sort || got.Seed != tt.want.Seed ||
+				!got.StartsFrom.Equal(tt.want.StartsFrom) || !got.StartsTo.Equal(tt.want.StartsTo) {
+				t.Errorf("ParseQuery(%q) = %+v, want %+v", tt.params, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryFreshSeed(t *testing.T) {
+	for _, params := range []string{"sort=random", "sort=random&seed=abc"} {
+		values, _ := url.ParseQuery(params)
+		if q := ParseQuery(values, ""); q.Sort != Random || q.Seed == 0 {
+			t.Errorf("ParseQuery(%q) = %+v, want a random order with a seed", params, q)
+		}
+	}
+}
+
+func TestQueryURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		want  string
+	}{
+		{name: "defaults left out", query: Query{Sort: Relevance}, want: "/courses"},
+		{name: "tag and search", query: Query{Text: "team lead", Tag: "business"}, want: "/courses?q=team+lead&tag=business"},
+		{name: "sort", query: Query{Sort: Name}, want: "/courses?sort=name"},
+		{name: "shuffle keeps its seed", query: Query{Sort: Random, Seed: 7}, want: "/courses?seed=7&sort=random"},
+		{name: "seed only with shuffle", query: Query{Sort: Start, Seed: 7}, want: "/courses?sort=start"},
+		{
+			name:  "start window",
+			query: Query{StartsFrom: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+			want:  "/courses?starts_from=2025-03-01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.URL("/courses"); got != tt.want {
+				t.Errorf("URL() = %q, want %q", got, tt.want)
+			}
+
+			// The URL parses back to the same query
+			u, _ := url.Parse(tt.query.URL("/courses"))
+			back := ParseQuery(u.Query(), "")
+			if back.URL("/courses") != tt.want {
+				t.Errorf("round trip = %q, want %q", back.URL("/courses"), tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryFields(t *testing.T) {
+	q := Query{Text: "lead", Tag: "business", Sort: Random, Seed: 7}
+	var got []string
+	for _, f := range q.Fields() {
+		got = append(got, f.Name+"="+f.Value)
+	}
+	want := []string{"q=lead", "seed=7", "sort=random", "tag=business"}
+	if len(got) != len(want) {
+		t.Fatalf("Fields() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Fields() = %q, want %q", got, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Package compare lines courses up field by field for the comparison view.
+package compare
+
+import (
+	"context"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// Max is how many courses can be compared at once.
+const Max = 3
+
+// Field is a compared course field. Its value names the i18n label
+// (compare.<field>) and the JSON row.
+type Field string
+
+const (
+	Duration          Field = "duration"
+	Delivery          Field = "delivery"
+	Frequency         Field = "frequency"
+	EntryRequirements Field = "entry_requirements"
+	Recognition       Field = "recognition"
+	PaymentOptions    Field = "payment_options"
+	StartDates        Field = "start_dates"
+)
+
+// row describes how to read a field: as text, or as sanitised HTML.
+type row struct {
+	field Field
+	html  bool
+	value func(ctx context.Context, c graph.CourseView, now time.Time) string
+}
+
+var rows = []row{
+	{field: Duration, value: func(ctx context.Context, c graph.CourseView, _ time.Time) string {
+		return i18n.Duration(ctx, c.Duration)
+	}},
+	{field: Delivery, value: func(ctx context.Context, c graph.CourseView, _ time.Time) string {
+		return i18n.Values(ctx, "delivery", c.Delivery)
+	}},
+	{field: Frequency, value: func(ctx context.Context, c graph.CourseView, _ time.Time) string {
+		return i18n.Values(ctx, "frequency", c.Frequency)
+	}},
+	{field: EntryRequirements, html: true, value: func(_ context.Context, c graph.CourseView, _ time.Time) string {
+		return c.EntryRequirements
+	}},
+	{field: Recognition, html: true, value: func(_ context.Context, c graph.CourseView, _ time.Time) string {
+		return c.ProfessionalRecognition
+	}},
+	{field: PaymentOptions, html: true, value: func(_ context.Context, c graph.CourseView, _ time.Time) string {
+		return c.PaymentOptions
+	}},
+	{field: StartDates, value: startDates},
+}
+
+// startDates lists the upcoming intakes, or the backend's start date when it
+// couldn't be parsed into any.
+func startDates(ctx context.Context, c graph.CourseView, now time.Time) string {
+	if len(c.Intakes) == 0 {
+		return c.StartDate
+	}
+	var dates []string
+	for _, intake := range c.UpcomingIntakes(now) {
+		dates = append(dates, i18n.Date(ctx, intake.Start))
+	}
+	return strings.Join(dates, ", ")
+}
+
+// Row is one field across the compared courses. Values are in course order;
+// when HTML is set they are sanitised markup.
+type Row struct {
+	Field   Field
+	Values  []string
+	HTML    bool
+	Differs bool
+}
+
+// Text returns the values as plain text.
+func (r Row) Text() []string {
+	if !r.HTML {
+		return r.Values
+	}
+	text := make([]string, len(r.Values))
+	for i, v := range r.Values {
+		text[i] = plainText(v)
+	}
+	return text
+}
+
+// Table is a comparison of courses.
+type Table struct {
+	Courses []graph.CourseView
+	Rows    []Row
+}
+
+// Build compares courses, formatting values for the locale in ctx. A row
+// differs when any two courses have a different value once markup, case and
+// spacing are ignored.
+func Build(ctx context.Context, courses []graph.CourseView, now time.Time) Table {
+	t := Table{Courses: courses}
+	for _, r := range rows {
+		out := Row{Field: r.field, HTML: r.html, Values: make([]string, len(courses))}
+		for i, c := range courses {
+			out.Values[i] = r.value(ctx, c, now)
+		}
+		text := out.Text()
+		for i := 1; i < len(text); i++ {
+			if normalise(text[i]) != normalise(text[0]) {
+				out.Differs = true
+			}
+		}
+		t.Rows = append(t.Rows, out)
+	}
+	return t
+}
+
+var stripTags = bluemonday.StrictPolicy()
+
+func plainText(s string) string {
+	return strings.TrimSpace(html.UnescapeString(stripTags.Sanitize(s)))
+}
+
+func normalise(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// ParseIDs reads course IDs from ids params, each either a single ID or a
+// comma-separated list. Invalid and repeated IDs are skipped, and only the
+// first Max are kept.
+func ParseIDs(values []string) []int {
+	var ids []int
+	for _, value := range values {
+		for _, s := range strings.Split(value, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil || id <= 0 || slices.Contains(ids, id) {
+				continue
+			}
+			if len(ids) == Max {
+				return ids
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// FormatIDs is the ids param for a list of IDs.
+func FormatIDs(ids []int) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.Itoa(id)
+	}
+	return strings.Join(s, ",")
+}
+
+// Toggle adds id to ids or removes it if present. Adding to a full list
+// leaves it unchanged and reports false.
+func Toggle(ids []int, id int) ([]int, bool) {
+	if i := slices.Index(ids, id); i >= 0 {
+		return slices.Delete(slices.Clone(ids), i, i+1), true
+	}
+	if len(ids) >= Max {
+		return ids, false
+	}
+	return append(slices.Clone(ids), id), true
+}
@@ -0,0 +1,115 @@
+package compare
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+)
+
+func TestParseIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   []int
+	}{
+		{name: "none", values: nil, want: nil},
+		{name: "separate params", values: []string{"3", "1"}, want: []int{3, 1}},
+		{name: "comma list", values: []string{"3, 1,2"}, want: []int{3, 1, 2}},
+		{name: "invalid skipped", values: []string{"x,0,-4,,7"}, want: []int{7}},
+		{name: "repeats skipped", values: []string{"5,5", "5,6"}, want: []int{5, 6}},
+		{name: "first max kept", values: []string{"1,2", "3,4,5"}, want: []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseIDs(tt.values); !slices.Equal(got, tt.want) {
+				t.Errorf("ParseIDs(%q) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToggle(t *testing.T) {
+	tests := []struct {
+		name   string
+		ids    []int
+		id     int
+		want   []int
+		wantOK bool
+	}{
+		{name: "add to empty", ids: nil, id: 4, want: []int{4}, wantOK: true},
+		{name: "add", ids: []int{1}, id: 4, want: []int{1, 4}, wantOK: true},
+		{name: "remove", ids: []int{1, 4, 2}, id: 4, want: []int{1, 2}, wantOK: true},
+		{name: "remove from full", ids: []int{1, 2, 3}, id: 1, want: []int{2, 3}, wantOK: true},
+		{name: "add to full", ids: []int{1, 2, 3}, id: 4, want: []int{1, 2, 3}, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := slices.Clone(tt.ids)
+			got, ok := Toggle(ids, tt.id)
+			if !slices.Equal(got, tt.want) || ok != tt.wantOK {
+				t.Errorf("Toggle(%v, %d) = %v, %v, want %v, %v", tt.ids, tt.id, got, ok, tt.want, tt.wantOK)
+			}
+			if !slices.Equal(ids, tt.ids) {
+				t.Errorf("Toggle modified its input: %v, want %v", ids, tt.ids)
+			}
+			if back := FormatIDs(got); !slices.Equal(ParseIDs([]string{back}), tt.want) {
+				t.Errorf("FormatIDs(%v) = %q, doesn't parse back", got, back)
+			}
+		})
+	}
+}
+
+func TestBuildDiffers(t *testing.T) {
+	course := func(entry, recognition, start string) graph.CourseView {
+		return graph.CourseView{
+			Course:                  models.Course{StartDate: start},
+			EntryRequirements:       entry,
+			ProfessionalRecognition: recognition,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		courses []graph.CourseView
+		differs []Field
+	}{
+		{name: "single course", courses: []graph.CourseView{course("<p>Year 12</p>", "", "Monthly")}},
+		{
+			name: "markup case and spacing ignored",
+			courses: []graph.CourseView{
+				course("<p>Year 12</p>", "<b>Recognised</b> &amp; accredited", "Monthly"),
+				course("year  12", "recognised & accredited", " monthly"),
+			},
+		},
+		{
+			name: "different values",
+			courses: []graph.CourseView{
+				course("<p>Year 12</p>", "", "Monthly"),
+				course("<p>Year 12</p>", "Recognised", "Monthly"),
+				course("<p>Year 10</p>", "", "Monthly"),
+			},
+			differs: []Field{EntryRequirements, Recognition},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := Build(context.Background(), tt.courses, time.Now())
+			var differs []Field
+			for _, r := range table.Rows {
+				if len(r.Values) != len(tt.courses) {
+					t.Errorf("row %s has %d values, want %d", r.Field, len(r.Values), len(tt.courses))
+				}
+				if r.Differs {
+					differs = append(differs, r.Field)
+				}
+			}
+			if !slices.Equal(differs, tt.differs) {
+				t.Errorf("differing rows = %v, want %v", differs, tt.differs)
+			}
+		})
+	}
+}
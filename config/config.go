@@ -0,0 +1,394 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Secret is a string that redacts itself when printed or logged.
+type Secret string
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return "[redacted]"
+}
+
+// GoString keeps %#v from leaking the value.
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+// Backend holds the GraphQL endpoint and the keys used to call it.
+type Backend struct {
+	GraphQLURL string
+	AnonKey    Secret
+	APIKey     Secret
+	// Translations is set when the backend serves localised course copy.
+	Translations bool
+}
+
+// Server holds the HTTP server timeouts.
+type Server struct {
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long the server keeps serving after failing
+	// readiness on shutdown, so load balancers notice before it stops
+	// accepting connections.
+	DrainDelay time.Duration
+}
+
+// Promotions selects where promotional banners are loaded from.
+type Promotions struct {
+	Source string // "file" or "graph"
+	File   string
+}
+
+// Auth holds the sign-in and cookie signing settings.
+type Auth struct {
+	// JWTSecret verifies the HS256 tokens posted to the auth callback.
+	// Sign-in doesn't start a session without it.
+	JWTSecret Secret
+	// SessionSecret signs the session and saved list cookies.
+	SessionSecret Secret
+	SessionTTL    time.Duration
+}
+
+// Enquiry selects how the detail view takes enquiries, and where leads from
+// the native form are kept and forwarded.
+type Enquiry struct {
+	Form          string // "iframe" or "native"
+	LeadsFile     string
+	WebhookURL    string
+	WebhookSecret Secret
+}
+
+// Native reports whether enquiries use the built-in form instead of the
+// ENQUIRE_FORM_URL iframe.
+func (e Enquiry) Native() bool {
+	return e.Form == "native"
+}
+
+// Lists says where signed-in users' saved and recently viewed courses are
+// kept.
+type Lists struct {
+	File string
+}
+
+// Geo says how the visitor's country is found and where geo-targeted
+// courses are offered.
+type Geo struct {
+	// CountryHeader is set by a trusted proxy, e.g. CF-IPCountry.
+	CountryHeader string
+	// Database is a CSV file mapping IP ranges to countries, used when the
+	// header is absent.
+	Database string
+	// Countries lists, as ISO 3166-1 alpha-2 codes, where geo-targeted
+	// courses are offered. Geo-targeting is off when it is empty.
+	Countries []string
+}
+
+// Config is the validated application configuration.
+type Config struct {
+	Env            string
+	Port           string
+	EnquireFormURL string
+	DefaultTag     string
+	CacheTTL       time.Duration
+	Backend        Backend
+	Server         Server
+	Promotions     Promotions
+	Enquiry        Enquiry
+	Auth           Auth
+	Lists          Lists
+	Geo            Geo
+	AllowedOrigins []string
+	Partners       []Partner
+	Tenants        []Tenant
+}
+
+// Development reports whether the service runs in development mode, where
+// some required values are allowed to fall back to local defaults.
+func (c *Config) Development() bool {
+	return c.Env == "development"
+}
+
+// String renders the configuration with secrets redacted.
+func (c *Config) String() string {
+	return fmt.Sprintf(
+		"env=%s port=%s graphql=%s anon_key=%s api_key=%s enquire_form_url=%s default_tag=%s cache_ttl=%s read_timeout=%s write_timeout=%s idle_timeout=%s shutdown_timeout=%s drain_delay=%s promotions=%s partners=%d tenants=%d",
+		c.Env, c.Port, c.Backend.GraphQLURL, c.Backend.AnonKey, c.Backend.APIKey,
+		c.EnquireFormURL, c.DefaultTag, c.CacheTTL,
+		c.Server.ReadTimeout, c.Server.WriteTimeout, c.Server.IdleTimeout, c.Server.ShutdownTimeout, c.Server.DrainDelay,
+		c.Promotions.Source, len(c.Partners), len(c.Tenants),
+	)
+}
+
+// defaults apply when a key is set nowhere else.
+var defaults = map[string]string{
+	"APP_ENV":           "production",
+	"PORT":              "8080",
+	"DEFAULT_TAG":       "marketing",
+	"ALLOWED_ORIGINS":   "*",
+	"PROMOTIONS_SOURCE": "file",
+	"PROMOTIONS_FILE":   "content/promotions.yaml",
+	"CACHE_TTL":         "5m",
+	"ENQUIRY_FORM":      "iframe",
+	"LEADS_FILE":        "data/leads.jsonl",
+	"SESSION_TTL":       "720h",
+	"LISTS_FILE":        "data/lists.json",
+	"SS_TRANSLATIONS":   "false",
+	"READ_TIMEOUT":      "10s",
+	"WRITE_TIMEOUT":     "30s",
+	"IDLE_TIMEOUT":      "120s",
+	"SHUTDOWN_TIMEOUT":  "25s",
+	"DRAIN_DELAY":       "5s",
+}
+
+// Load builds the configuration. Values are resolved in order of precedence:
+//
+//  1. process environment
+//  2. .env in the working directory (never overrides the environment)
+//  3. the YAML or TOML file named by CONFIG_FILE
+//  4. built-in defaults
+//
+// All problems are reported together so a bad deploy can be fixed in one go.
+func Load() (*Config, error) {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	var file fileConfig
+	fileValues := map[string]string{}
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		var err error
+		file, err = readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		fileValues = file.values()
+	}
+
+	lookup := func(key string) string {
+		if v, ok := os.LookupEnv(key); ok && v != "" {
+			return v
+		}
+		if v := fileValues[key]; v != "" {
+			return v
+		}
+		return defaults[key]
+	}
+
+	var errs []error
+	duration := func(key string) time.Duration {
+		v := lookup(key)
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			errs = append(errs, fmt.Errorf("%s: invalid duration %q", key, v))
+		}
+		return d
+	}
+	// delay is a duration that may be zero
+	delay := func(key string) time.Duration {
+		v := lookup(key)
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			errs = append(errs, fmt.Errorf("%s: invalid duration %q", key, v))
+		}
+		return d
+	}
+
+	boolean := func(key string) bool {
+		v := lookup(key)
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid boolean %q", key, v))
+		}
+		return b
+	}
+
+	cfg := &Config{
+		Env:            lookup("APP_ENV"),
+		Port:           lookup("PORT"),
+		EnquireFormURL: lookup("ENQUIRE_FORM_URL"),
+		DefaultTag:     lookup("DEFAULT_TAG"),
+		CacheTTL:       duration("CACHE_TTL"),
+		Backend: Backend{
+			GraphQLURL:   lookup("SS_GRAPHQL"),
+			AnonKey:      Secret(lookup("SS_ANON_KEY")),
+			APIKey:       Secret(lookup("SS_API_KEY")),
+			Translations: boolean("SS_TRANSLATIONS"),
+		},
+		Server: Server{
+			ReadTimeout:     duration("READ_TIMEOUT"),
+			WriteTimeout:    duration("WRITE_TIMEOUT"),
+			IdleTimeout:     duration("IDLE_TIMEOUT"),
+			ShutdownTimeout: duration("SHUTDOWN_TIMEOUT"),
+			DrainDelay:      delay("DRAIN_DELAY"),
+		},
+		Promotions: Promotions{
+			Source: lookup("PROMOTIONS_SOURCE"),
+			File:   lookup("PROMOTIONS_FILE"),
+		},
+		Enquiry: Enquiry{
+			Form:          lookup("ENQUIRY_FORM"),
+			LeadsFile:     lookup("LEADS_FILE"),
+			WebhookURL:    lookup("LEADS_WEBHOOK_URL"),
+			WebhookSecret: Secret(lookup("LEADS_WEBHOOK_SECRET")),
+		},
+		Auth: Auth{
+			JWTSecret:     Secret(lookup("AUTH_JWT_SECRET")),
+			SessionSecret: Secret(lookup("SESSION_SECRET")),
+			SessionTTL:    duration("SESSION_TTL"),
+		},
+		Lists: Lists{
+			File: lookup("LISTS_FILE"),
+		},
+		Geo: Geo{
+			CountryHeader: lookup("GEO_COUNTRY_HEADER"),
+			Database:      lookup("GEOIP_DATABASE"),
+			Countries:     countryList(lookup("GEO_COUNTRIES")),
+		},
+		AllowedOrigins: splitList(lookup("ALLOWED_ORIGINS")),
+		Partners:       file.Partners,
+	}
+
+	if cfg.Auth.SessionSecret == "" {
+		// Cookies still work, but only until the next restart and only on
+		// this instance
+		log.Println("SESSION_SECRET not set, signing cookies with a random key")
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			errs = append(errs, fmt.Errorf("SESSION_SECRET: generating a key: %w", err))
+		}
+		cfg.Auth.SessionSecret = Secret(hex.EncodeToString(key))
+	}
+
+	if cfg.EnquireFormURL == "" && cfg.Development() {
+		cfg.EnquireFormURL = "http://localhost:8081"
+	}
+
+	cfg.Tenants = buildTenants(file.Tenants, cfg)
+
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return cfg, nil
+}
+
+// Validate checks that required values are present and well formed.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Env != "development" && c.Env != "production" {
+		errs = append(errs, fmt.Errorf("APP_ENV: must be development or production, got %q", c.Env))
+	}
+	if c.Port == "" {
+		errs = append(errs, errors.New("PORT: is required"))
+	}
+	if err := validateURL(c.Backend.GraphQLURL); err != nil {
+		errs = append(errs, fmt.Errorf("SS_GRAPHQL: %w", err))
+	}
+	if c.Backend.AnonKey == "" {
+		errs = append(errs, errors.New("SS_ANON_KEY: is required"))
+	}
+	if c.Backend.APIKey == "" {
+		errs = append(errs, errors.New("SS_API_KEY: is required"))
+	}
+	if c.Enquiry.Form != "iframe" && c.Enquiry.Form != "native" {
+		errs = append(errs, fmt.Errorf("ENQUIRY_FORM: must be iframe or native, got %q", c.Enquiry.Form))
+	}
+	// The iframe URL is only needed when the native form isn't used
+	if c.EnquireFormURL != "" || !c.Enquiry.Native() {
+		if err := validateURL(c.EnquireFormURL); err != nil {
+			errs = append(errs, fmt.Errorf("ENQUIRE_FORM_URL: %w", err))
+		}
+	}
+	if c.Enquiry.WebhookURL != "" {
+		if err := validateURL(c.Enquiry.WebhookURL); err != nil {
+			errs = append(errs, fmt.Errorf("LEADS_WEBHOOK_URL: %w", err))
+		}
+	}
+	if err := validateCountries(c.Geo.Countries); err != nil {
+		errs = append(errs, fmt.Errorf("GEO_COUNTRIES: %w", err))
+	}
+	if c.Geo.Database != "" {
+		if _, err := os.Stat(c.Geo.Database); err != nil {
+			errs = append(errs, fmt.Errorf("GEOIP_DATABASE: %w", err))
+		}
+	}
+	if c.Promotions.Source != "file" && c.Promotions.Source != "graph" {
+		errs = append(errs, fmt.Errorf("PROMOTIONS_SOURCE: must be file or graph, got %q", c.Promotions.Source))
+	}
+	if err := validatePartners(c.Partners); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateTenants(c.Tenants, c.Partners, !c.Enquiry.Native()); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateURL(raw string) error {
+	if raw == "" {
+		return errors.New("is required")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("must be an http(s) URL, got %q", raw)
+	}
+	if strings.TrimSpace(u.Host) == "" {
+		return fmt.Errorf("missing host in %q", raw)
+	}
+	return nil
+}
+
+// splitList parses a comma separated value, dropping empty entries.
+func splitList(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// countryList parses a comma separated list of country codes, upper-casing
+// them.
+func countryList(v string) []string {
+	countries := splitList(v)
+	for i, code := range countries {
+		countries[i] = strings.ToUpper(code)
+	}
+	return countries
+}
+
+func validateCountries(countries []string) error {
+	for _, code := range countries {
+		if len(code) != 2 || strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+			return fmt.Errorf("%q is not a two-letter country code", code)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setEnv clears every setting from the environment, then sets env. Empty
+// values count as unset, so clearing doesn't need to unset.
+func setEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+	t.Setenv("CONFIG_FILE", "")
+	for key := range (fileConfig{}).values() {
+		t.Setenv(key, "")
+	}
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+}
+
+// writeFile writes a config file named name and returns its path.
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+var required = map[string]string{
+	"SS_GRAPHQL":       "https://env.example.com/graphql",
+	"SS_ANON_KEY":      "anon",
+	"SS_API_KEY":       "api",
+	"ENQUIRE_FORM_URL": "https://forms.example.com",
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	yamlFile := `
+port: "9000"
+default_tag: file-tag
+cache_ttl: 1m
+graphql:
+  url: https://file.example.com/graphql
+  translations: true
+server:
+  read_timeout: 3s
+`
+	tomlFile := `
+port = "9000"
+default_tag = "file-tag"
+cache_ttl = "1m"
+
+[graphql]
+url = "https://file.example.com/graphql"
+translations = true
+
+[server]
+read_timeout = "3s"
+`
+
+	tests := []struct {
+		name string
+		file string // config file name and content, if any
+		data string
+		env  map[string]string
+		want func(c *Config) []any // got, want pairs
+	}{
+		{
+			name: "defaults",
+			want: func(c *Config) []any {
+				return []any{
+					c.Port, "8080",
+					c.DefaultTag, "marketing",
+					c.CacheTTL, 5 * time.Minute,
+					c.Server.ReadTimeout, 10 * time.Second,
+					c.Backend.Translations, false,
+					c.Backend.GraphQLURL, "https://env.example.com/graphql",
+				}
+			},
+		},
+		{
+			name: "yaml file over defaults",
+			file: "config.yaml",
+			data: yamlFile,
+			env:  map[string]string{"SS_GRAPHQL": ""},
+			want: func(c *Config) []any {
+				return []any{
+					c.Port, "9000",
+					c.DefaultTag, "file-tag",
+					c.CacheTTL, time.Minute,
+					c.Server.ReadTimeout, 3 * time.Second,
+					c.Server.WriteTimeout, 30 * time.Second,
+					c.Backend.Translations, true,
+					c.Backend.GraphQLURL, "https://file.example.com/graphql",
+				}
+			},
+		},
+		{
+			name: "toml file over defaults",
+			file: "config.toml",
+			data: tomlFile,
+			env:  map[string]string{"SS_GRAPHQL": ""},
+			want: func(c *Config) []any {
+				return []any{
+					c.Port, "9000",
+					c.CacheTTL, time.Minute,
+					c.Backend.Translations, true,
+					c.Backend.GraphQLURL, "https://file.example.com/graphql",
+				}
+			},
+		},
+		{
+			name: "environment over file",
+			file: "config.yaml",
+			data: yamlFile,
+			env:  map[string]string{"PORT": "7000", "SS_TRANSLATIONS": "false", "READ_TIMEOUT": "4s"},
+			want: func(c *Config) []any {
+				return []any{
+					c.Port, "7000",
+					c.DefaultTag, "file-tag",
+					c.Server.ReadTimeout, 4 * time.Second,
+					c.Backend.Translations, false,
+					c.Backend.GraphQLURL, "https://env.example.com/graphql",
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{}
+			for k, v := range required {
+				env[k] = v
+			}
+			for k, v := range tt.env {
+				env[k] = v
+			}
+			if tt.file != "" {
+				env["CONFIG_FILE"] = writeFile(t, tt.file, tt.data)
+			}
+			setEnv(t, env)
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pairs := tt.want(cfg)
+			for i := 0; i < len(pairs); i += 2 {
+				if pairs[i] != pairs[i+1] {
+					t.Errorf("value %d = %v, want %v", i/2, pairs[i], pairs[i+1])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		env  map[string]string
+		want []string // substrings of the error
+	}{
+		{
+			name: "missing required",
+			env:  map[string]string{"SS_GRAPHQL": "", "SS_API_KEY": ""},
+			want: []string{"SS_GRAPHQL: is required", "SS_API_KEY: is required"},
+		},
+		{
+			name: "all problems together",
+			env:  map[string]string{"CACHE_TTL": "soon", "SS_TRANSLATIONS": "maybe", "APP_ENV": "staging", "GEO_COUNTRIES": "au,aus"},
+			want: []string{"CACHE_TTL", "SS_TRANSLATIONS", "APP_ENV", `GEO_COUNTRIES: "AUS"`},
+		},
+		{
+			name: "zero duration",
+			env:  map[string]string{"READ_TIMEOUT": "0s"},
+			want: []string{"READ_TIMEOUT"},
+		},
+		{
+			name: "bad url",
+			env:  map[string]string{"SS_GRAPHQL": "ftp://example.com"},
+			want: []string{"SS_GRAPHQL: must be an http(s) URL"},
+		},
+		{
+			name: "native form needs no iframe url",
+			env:  map[string]string{"ENQUIRY_FORM": "native", "ENQUIRE_FORM_URL": ""},
+			want: nil,
+		},
+		{
+			name: "unsupported file",
+			file: "config.json",
+			data: "{}",
+			want: []string{"unsupported extension"},
+		},
+		{
+			name: "malformed file",
+			file: "config.yaml",
+			data: "port: [",
+			want: []string{"decoding config file"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{}
+			for k, v := range required {
+				env[k] = v
+			}
+			for k, v := range tt.env {
+				env[k] = v
+			}
+			if tt.file != "" {
+				env["CONFIG_FILE"] = writeFile(t, tt.file, tt.data)
+			}
+			setEnv(t, env)
+
+			_, err := Load()
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Load() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Load() succeeded, want an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q doesn't mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSecretRedacted(t *testing.T) {
+	setEnv(t, required)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := cfg.String(); strings.Contains(s, "=anon ") || strings.Contains(s, "=api ") {
+		t.Errorf("String() leaks a secret: %s", s)
+	}
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig is the on-disk layout of CONFIG_FILE. Durations are strings such
+// as "30s" so the same file reads naturally in YAML and TOML.
+type fileConfig struct {
+	Env            string      `yaml:"env" toml:"env"`
+	Port           string      `yaml:"port" toml:"port"`
+	EnquireFormURL string      `yaml:"enquire_form_url" toml:"enquire_form_url"`
+	DefaultTag     string      `yaml:"default_tag" toml:"default_tag"`
+	CacheTTL       string      `yaml:"cache_ttl" toml:"cache_ttl"`
+	GraphQL        graphQLFile `yaml:"graphql" toml:"graphql"`
+	Server         struct {
+		ReadTimeout     string `yaml:"read_timeout" toml:"read_timeout"`
+		WriteTimeout    string `yaml:"write_timeout" toml:"write_timeout"`
+		IdleTimeout     string `yaml:"idle_timeout" toml:"idle_timeout"`
+		ShutdownTimeout string `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+		DrainDelay      string `yaml:"drain_delay" toml:"drain_delay"`
+	} `yaml:"server" toml:"server"`
+	Promotions struct {
+		Source string `yaml:"source" toml:"source"`
+		File   string `yaml:"file" toml:"file"`
+	} `yaml:"promotions" toml:"promotions"`
+	Enquiry struct {
+		Form          string `yaml:"form" toml:"form"`
+		LeadsFile     string `yaml:"leads_file" toml:"leads_file"`
+		WebhookURL    string `yaml:"webhook_url" toml:"webhook_url"`
+		WebhookSecret string `yaml:"webhook_secret" toml:"webhook_secret"`
+	} `yaml:"enquiry" toml:"enquiry"`
+	Auth struct {
+		JWTSecret     string `yaml:"jwt_secret" toml:"jwt_secret"`
+		SessionSecret string `yaml:"session_secret" toml:"session_secret"`
+		SessionTTL    string `yaml:"session_ttl" toml:"session_ttl"`
+	} `yaml:"auth" toml:"auth"`
+	Lists struct {
+		File string `yaml:"file" toml:"file"`
+	} `yaml:"lists" toml:"lists"`
+	Geo struct {
+		CountryHeader string `yaml:"country_header" toml:"country_header"`
+		Database      string `yaml:"database" toml:"database"`
+		Countries     string `yaml:"countries" toml:"countries"`
+	} `yaml:"geo" toml:"geo"`
+	AllowedOrigins string       `yaml:"allowed_origins" toml:"allowed_origins"`
+	Partners       []Partner    `yaml:"partners" toml:"partners"`
+	Tenants        []tenantFile `yaml:"tenants" toml:"tenants"`
+}
+
+// graphQLFile is the on-disk layout of a backend section.
+type graphQLFile struct {
+	URL          string `yaml:"url" toml:"url"`
+	AnonKey      string `yaml:"anon_key" toml:"anon_key"`
+	APIKey       string `yaml:"api_key" toml:"api_key"`
+	Translations *bool  `yaml:"translations" toml:"translations"`
+}
+
+// readFile decodes a YAML or TOML config file.
+func readFile(path string) (fileConfig, error) {
+	var fc fileConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fc, fmt.Errorf("reading config file: %w", err)
+	}
+
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &fc)
+	case ".toml":
+		err = toml.Unmarshal(data, &fc)
+	default:
+		return fc, fmt.Errorf("config file %s: unsupported extension %q (want .yaml, .yml or .toml)", path, ext)
+	}
+	if err != nil {
+		return fc, fmt.Errorf("decoding config file %s: %w", path, err)
+	}
+	return fc, nil
+}
+
+// values flattens the scalar settings onto the same keys as the environment
+// variables, so precedence is a simple lookup. Structured sections such as
+// partners and tenants only come from the file.
+func (fc fileConfig) values() map[string]string {
+	return map[string]string{
+		"APP_ENV":              fc.Env,
+		"PORT":                 fc.Port,
+		"ENQUIRE_FORM_URL":     fc.EnquireFormURL,
+		"DEFAULT_TAG":          fc.DefaultTag,
+		"ALLOWED_ORIGINS":      fc.AllowedOrigins,
+		"PROMOTIONS_SOURCE":    fc.Promotions.Source,
+		"PROMOTIONS_FILE":      fc.Promotions.File,
+		"ENQUIRY_FORM":         fc.Enquiry.Form,
+		"LEADS_FILE":           fc.Enquiry.LeadsFile,
+		"LEADS_WEBHOOK_URL":    fc.Enquiry.WebhookURL,
+		"LEADS_WEBHOOK_SECRET": fc.Enquiry.WebhookSecret,
+		"AUTH_JWT_SECRET":      fc.Auth.JWTSecret,
+		"SESSION_SECRET":       fc.Auth.SessionSecret,
+		"SESSION_TTL":          fc.Auth.SessionTTL,
+		"LISTS_FILE":           fc.Lists.File,
+		"GEO_COUNTRY_HEADER":   fc.Geo.CountryHeader,
+		"GEOIP_DATABASE":       fc.Geo.Database,
+		"GEO_COUNTRIES":        fc.Geo.Countries,
+		"CACHE_TTL":            fc.CacheTTL,
+		"SS_GRAPHQL":           fc.GraphQL.URL,
+		"SS_ANON_KEY":          fc.GraphQL.AnonKey,
+		"SS_API_KEY":           fc.GraphQL.APIKey,
+		"SS_TRANSLATIONS":      optionalBool(fc.GraphQL.Translations),
+		"READ_TIMEOUT":         fc.Server.ReadTimeout,
+		"WRITE_TIMEOUT":        fc.Server.WriteTimeout,
+		"IDLE_TIMEOUT":         fc.Server.IdleTimeout,
+		"SHUTDOWN_TIMEOUT":     fc.Server.ShutdownTimeout,
+		"DRAIN_DELAY":          fc.Server.DrainDelay,
+	}
+}
+
+// optionalBool renders an optional file flag so unset falls through to the
+// defaults.
+func optionalBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
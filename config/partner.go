@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"golang.org/x/text/language"
+)
+
+// Embed modes understood by the SDK.
+var (
+	mainModes   = []string{"inline", "modal"}
+	detailModes = []string{"inline", "modal", "inlineOnModal"}
+)
+
+// Features partners can switch on in the embed.
+const (
+	FeatureShuffle = "shuffle"
+	FeatureReset   = "reset"
+	FeatureEnquire = "enquire"
+)
+
+var knownFeatures = []string{FeatureShuffle, FeatureReset, FeatureEnquire}
+
+// Partner is a site embedding the SDK, identified by the public key passed to
+// MicroFrontend.init. Its record drives the embed so layout changes don't need
+// the partner to redeploy.
+type Partner struct {
+	Key            string            `yaml:"key" toml:"key"`
+	Name           string            `yaml:"name" toml:"name"`
+	MainMode       string            `yaml:"main_mode" toml:"main_mode"`
+	DetailMode     string            `yaml:"detail_mode" toml:"detail_mode"`
+	DefaultKeyword string            `yaml:"default_keyword" toml:"default_keyword"`
+	Locale         string            `yaml:"locale" toml:"locale"`
+	Theme          map[string]string `yaml:"theme" toml:"theme"`
+	Features       []string          `yaml:"features" toml:"features"`
+	EnquireFormURL string            `yaml:"enquire_form_url" toml:"enquire_form_url"`
+}
+
+// HasFeature reports whether the partner has the feature switched on.
+func (p Partner) HasFeature(name string) bool {
+	return slices.Contains(p.Features, name)
+}
+
+// Partner looks up a partner by key.
+func (c *Config) Partner(key string) (Partner, bool) {
+	if key == "" {
+		return Partner{}, false
+	}
+	for _, p := range c.Partners {
+		if p.Key == key {
+			return p, true
+		}
+	}
+	return Partner{}, false
+}
+
+func validatePartners(partners []Partner) error {
+	var errs []error
+	seen := map[string]bool{}
+
+	for i, p := range partners {
+		field := fmt.Sprintf("partners[%d]", i)
+		if p.Key == "" {
+			errs = append(errs, fmt.Errorf("%s.key: is required", field))
+		} else if seen[p.Key] {
+			errs = append(errs, fmt.Errorf("%s.key: duplicate key %q", field, p.Key))
+		}
+		seen[p.Key] = true
+
+		if p.MainMode != "" && !slices.Contains(mainModes, p.MainMode) {
+			errs = append(errs, fmt.Errorf("%s.main_mode: must be one of %v, got %q", field, mainModes, p.MainMode))
+		}
+		if p.DetailMode != "" && !slices.Contains(detailModes, p.DetailMode) {
+			errs = append(errs, fmt.Errorf("%s.detail_mode: must be one of %v, got %q", field, detailModes, p.DetailMode))
+		}
+		for _, f := range p.Features {
+			if !slices.Contains(knownFeatures, f) {
+				errs = append(errs, fmt.Errorf("%s.features: unknown feature %q", field, f))
+			}
+		}
+		if p.Locale != "" {
+			if _, err := language.Parse(p.Locale); err != nil {
+				errs = append(errs, fmt.Errorf("%s.locale: %w", field, err))
+			}
+		}
+		if p.EnquireFormURL != "" {
+			if err := validateURL(p.EnquireFormURL); err != nil {
+				errs = append(errs, fmt.Errorf("%s.enquire_form_url: %w", field, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
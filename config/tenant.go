@@ -0,0 +1,185 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultTenantID names the tenant built from the top-level settings. It
+// serves every request that doesn't resolve to a configured tenant.
+const DefaultTenantID = "default"
+
+// Tenant is an organisation served by this deployment with its own backend,
+// look and enquiry flow. Requests are matched to a tenant by API key, SDK
+// partner key, path prefix or host, in that order.
+type Tenant struct {
+	ID             string
+	Name           string
+	Hosts          []string
+	PathPrefix     string
+	APIKeys        []Secret
+	PartnerKeys    []string
+	Backend        Backend
+	DefaultTag     string
+	Theme          map[string]string
+	EnquireFormURL string
+	AllowedOrigins []string
+	// GeoCountries are where the tenant's geo-targeted courses are offered.
+	GeoCountries []string
+}
+
+// tenantFile is the on-disk layout of a tenant. Each graphql setting left
+// empty is inherited from the top-level graphql section on its own, so a
+// tenant can override just its keys or just the URL.
+type tenantFile struct {
+	ID             string            `yaml:"id" toml:"id"`
+	Name           string            `yaml:"name" toml:"name"`
+	Hosts          []string          `yaml:"hosts" toml:"hosts"`
+	PathPrefix     string            `yaml:"path_prefix" toml:"path_prefix"`
+	APIKeys        []string          `yaml:"api_keys" toml:"api_keys"`
+	PartnerKeys    []string          `yaml:"partner_keys" toml:"partner_keys"`
+	GraphQL        graphQLFile       `yaml:"graphql" toml:"graphql"`
+	DefaultTag     string            `yaml:"default_tag" toml:"default_tag"`
+	Theme          map[string]string `yaml:"theme" toml:"theme"`
+	EnquireFormURL string            `yaml:"enquire_form_url" toml:"enquire_form_url"`
+	AllowedOrigins []string          `yaml:"allowed_origins" toml:"allowed_origins"`
+	GeoCountries   []string          `yaml:"geo_countries" toml:"geo_countries"`
+}
+
+// DefaultTenant returns the tenant described by the top-level settings.
+func (c *Config) DefaultTenant() Tenant {
+	return Tenant{
+		ID:             DefaultTenantID,
+		Backend:        c.Backend,
+		DefaultTag:     c.DefaultTag,
+		EnquireFormURL: c.EnquireFormURL,
+		AllowedOrigins: c.AllowedOrigins,
+		GeoCountries:   c.Geo.Countries,
+	}
+}
+
+// buildTenants fills in inherited settings so every tenant is complete.
+func buildTenants(files []tenantFile, c *Config) []Tenant {
+	tenants := make([]Tenant, 0, len(files))
+	for _, tf := range files {
+		t := Tenant{
+			ID:             tf.ID,
+			Name:           tf.Name,
+			Hosts:          tf.Hosts,
+			PathPrefix:     strings.TrimSuffix(tf.PathPrefix, "/"),
+			PartnerKeys:    tf.PartnerKeys,
+			Backend:        c.Backend,
+			DefaultTag:     tf.DefaultTag,
+			Theme:          tf.Theme,
+			EnquireFormURL: tf.EnquireFormURL,
+			AllowedOrigins: tf.AllowedOrigins,
+		}
+		for _, code := range tf.GeoCountries {
+			t.GeoCountries = append(t.GeoCountries, strings.ToUpper(code))
+		}
+		for _, k := range tf.APIKeys {
+			t.APIKeys = append(t.APIKeys, Secret(k))
+		}
+		if tf.GraphQL.URL != "" {
+			t.Backend.GraphQLURL = tf.GraphQL.URL
+		}
+		if tf.GraphQL.AnonKey != "" {
+			t.Backend.AnonKey = Secret(tf.GraphQL.AnonKey)
+		}
+		if tf.GraphQL.APIKey != "" {
+			t.Backend.APIKey = Secret(tf.GraphQL.APIKey)
+		}
+		if tf.GraphQL.Translations != nil {
+			t.Backend.Translations = *tf.GraphQL.Translations
+		}
+		if t.DefaultTag == "" {
+			t.DefaultTag = c.DefaultTag
+		}
+		if t.EnquireFormURL == "" {
+			t.EnquireFormURL = c.EnquireFormURL
+		}
+		if len(t.AllowedOrigins) == 0 {
+			t.AllowedOrigins = c.AllowedOrigins
+		}
+		if len(t.GeoCountries) == 0 {
+			t.GeoCountries = c.Geo.Countries
+		}
+		tenants = append(tenants, t)
+	}
+	return tenants
+}
+
+func validateTenants(tenants []Tenant, partners []Partner, requireForm bool) error {
+	var errs []error
+	ids := map[string]bool{DefaultTenantID: true}
+	claimed := map[string]string{}
+
+	claim := func(kind, value, id string) {
+		key := kind + ":" + value
+		if owner, ok := claimed[key]; ok {
+			errs = append(errs, fmt.Errorf("tenants[%s]: %s %q already used by tenant %q", id, kind, value, owner))
+			return
+		}
+		claimed[key] = id
+	}
+
+	for i, t := range tenants {
+		if t.ID == "" {
+			errs = append(errs, fmt.Errorf("tenants[%d].id: is required", i))
+			continue
+		}
+		if ids[t.ID] {
+			errs = append(errs, fmt.Errorf("tenants[%d].id: duplicate or reserved id %q", i, t.ID))
+		}
+		ids[t.ID] = true
+
+		if len(t.Hosts) == 0 && t.PathPrefix == "" && len(t.APIKeys) == 0 && len(t.PartnerKeys) == 0 {
+			errs = append(errs, fmt.Errorf("tenants[%s]: needs at least one of hosts, path_prefix, api_keys or partner_keys", t.ID))
+		}
+		if t.PathPrefix != "" && !strings.HasPrefix(t.PathPrefix, "/") {
+			errs = append(errs, fmt.Errorf("tenants[%s].path_prefix: must start with /", t.ID))
+		}
+		for _, h := range t.Hosts {
+			claim("host", strings.ToLower(h), t.ID)
+		}
+		if t.PathPrefix != "" {
+			claim("path prefix", t.PathPrefix, t.ID)
+		}
+		for _, k := range t.APIKeys {
+			claim("api key", string(k), t.ID)
+		}
+		for _, k := range t.PartnerKeys {
+			claim("partner key", k, t.ID)
+			if !hasPartner(partners, k) {
+				errs = append(errs, fmt.Errorf("tenants[%s].partner_keys: unknown partner %q", t.ID, k))
+			}
+		}
+
+		if err := validateURL(t.Backend.GraphQLURL); err != nil {
+			errs = append(errs, fmt.Errorf("tenants[%s].graphql.url: %w", t.ID, err))
+		}
+		if t.Backend.AnonKey == "" || t.Backend.APIKey == "" {
+			errs = append(errs, fmt.Errorf("tenants[%s].graphql: anon_key and api_key are required", t.ID))
+		}
+		if err := validateCountries(t.GeoCountries); err != nil {
+			errs = append(errs, fmt.Errorf("tenants[%s].geo_countries: %w", t.ID, err))
+		}
+		if t.EnquireFormURL != "" || requireForm {
+			if err := validateURL(t.EnquireFormURL); err != nil {
+				errs = append(errs, fmt.Errorf("tenants[%s].enquire_form_url: %w", t.ID, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func hasPartner(partners []Partner, key string) bool {
+	for _, p := range partners {
+		if p.Key == key {
+			return true
+		}
+	}
+	return false
+}
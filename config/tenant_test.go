@@ -0,0 +1,133 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildTenants(t *testing.T) {
+	enabled := true
+	c := &Config{
+		DefaultTag:     "marketing",
+		EnquireFormURL: "https://forms.example.com",
+		AllowedOrigins: []string{"*"},
+		Backend:        Backend{GraphQLURL: "https://top.example.com/graphql", AnonKey: "top-anon", APIKey: "top-api"},
+		Geo:            Geo{Countries: []string{"AU"}},
+	}
+
+	tests := []struct {
+		name string
+		file tenantFile
+		want Tenant
+	}{
+		{
+			name: "inherits everything",
+			file: tenantFile{ID: "acme", Hosts: []string{"acme.example.com"}},
+			want: Tenant{
+				Backend:        c.Backend,
+				DefaultTag:     "marketing",
+				EnquireFormURL: "https://forms.example.com",
+				GeoCountries:   []string{"AU"},
+			},
+		},
+		{
+			name: "overrides keys only",
+			file: tenantFile{ID: "acme", GraphQL: graphQLFile{AnonKey: "acme-anon", APIKey: "acme-api"}},
+			want: Tenant{
+				Backend:        Backend{GraphQLURL: "https://top.example.com/graphql", AnonKey: "acme-anon", APIKey: "acme-api"},
+				DefaultTag:     "marketing",
+				EnquireFormURL: "https://forms.example.com",
+				GeoCountries:   []string{"AU"},
+			},
+		},
+		{
+			name: "overrides url and settings",
+			file: tenantFile{
+				ID:             "acme",
+				PathPrefix:     "/acme/",
+				GraphQL:        graphQLFile{URL: "https://acme.example.com/graphql", Translations: &enabled},
+				DefaultTag:     "acme",
+				EnquireFormURL: "https://acme.example.com/enquire",
+				GeoCountries:   []string{"nz"},
+			},
+			want: Tenant{
+				PathPrefix:     "/acme",
+				Backend:        Backend{GraphQLURL: "https://acme.example.com/graphql", AnonKey: "top-anon", APIKey: "top-api", Translations: true},
+				DefaultTag:     "acme",
+				EnquireFormURL: "https://acme.example.com/enquire",
+				GeoCountries:   []string{"NZ"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildTenants([]tenantFile{tt.file}, c)[0]
+			if got.PathPrefix != tt.want.PathPrefix || got.Backend != tt.want.Backend ||
+				got.DefaultTag != tt.want.DefaultTag || got.EnquireFormURL != tt.want.EnquireFormURL ||
+				strings.Join(got.GeoCountries, ",") != strings.Join(tt.want.GeoCountries, ",") {
+				t.Errorf("buildTenants() = %+v, want %+v", got, tt.want)
+			}
+			if len(got.AllowedOrigins) != 1 || got.AllowedOrigins[0] != "*" {
+				t.Errorf("AllowedOrigins = %v, want inherited [*]", got.AllowedOrigins)
+			}
+		})
+	}
+}
+
+func TestValidateTenants(t *testing.T) {
+	backend := Backend{GraphQLURL: "https://example.com/graphql", AnonKey: "anon", APIKey: "api"}
+	tenant := func(id string, hosts ...string) Tenant {
+		return Tenant{ID: id, Hosts: hosts, Backend: backend, EnquireFormURL: "https://forms.example.com"}
+	}
+	partners := []Partner{{Key: "pk_acme"}}
+
+	tests := []struct {
+		name    string
+		tenants []Tenant
+		want    []string // substrings of the error, none when valid
+	}{
+		{name: "valid", tenants: []Tenant{tenant("acme", "acme.example.com"), tenant("globex", "globex.example.com")}},
+		{name: "missing id", tenants: []Tenant{tenant("", "acme.example.com")}, want: []string{"tenants[0].id: is required"}},
+		{name: "reserved id", tenants: []Tenant{tenant(DefaultTenantID, "acme.example.com")}, want: []string{"reserved id"}},
+		{name: "no matcher", tenants: []Tenant{tenant("acme")}, want: []string{"needs at least one of"}},
+		{
+			name:    "host claimed twice",
+			tenants: []Tenant{tenant("acme", "shared.example.com"), tenant("globex", "SHARED.example.com")},
+			want:    []string{`host "shared.example.com" already used by tenant "acme"`},
+		},
+		{
+			name:    "relative prefix",
+			tenants: []Tenant{{ID: "acme", PathPrefix: "acme", Backend: backend}},
+			want:    []string{"path_prefix: must start with /"},
+		},
+		{
+			name:    "unknown partner",
+			tenants: []Tenant{{ID: "acme", PartnerKeys: []string{"pk_acme", "pk_other"}, Backend: backend}},
+			want:    []string{`unknown partner "pk_other"`},
+		},
+		{
+			name:    "missing keys",
+			tenants: []Tenant{{ID: "acme", Hosts: []string{"acme.example.com"}, Backend: Backend{GraphQLURL: backend.GraphQLURL}}},
+			want:    []string{"anon_key and api_key are required"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTenants(tt.tenants, partners, false)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("validateTenants() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("validateTenants() succeeded, want an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q doesn't mention %q", err, want)
+				}
+			}
+		})
+	}
+}
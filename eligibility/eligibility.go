@@ -0,0 +1,215 @@
+// Package eligibility runs the self-check questionnaire: it asks a learner
+// only the questions a course's entry rules need, one at a time, and tells
+// them whether they are likely to qualify.
+package eligibility
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+)
+
+// Question is something the questionnaire asks. Its value is also the URL
+// param that carries the answer.
+type Question string
+
+const (
+	Age           Question = "age"
+	Qualification Question = "qualification"
+	English       Question = "english"
+	Residency     Question = "residency"
+	// Experience is only asked when the course takes recognition of prior
+	// learning.
+	Experience Question = "experience"
+)
+
+// Choices for the questions that aren't free input. Qualifications and
+// EnglishLevels are ordered lowest first, so a rule is met by its level or
+// any above it.
+var (
+	Qualifications = []string{"none", "year_10", "year_12", "certificate", "diploma", "degree"}
+	// EnglishLevels are CEFR levels; native speakers meet any rule.
+	EnglishLevels    = []string{"A1", "A2", "B1", "B2", "C1", "C2", "native"}
+	ResidencyStatus  = []string{"citizen", "permanent_resident", "visa", "international"}
+	ExperienceLevels = []string{"none", "under_2", "2_to_5", "over_5"}
+)
+
+// Ages outside this range are taken as typos.
+const (
+	minAnswerAge = 10
+	maxAnswerAge = 120
+)
+
+// rplExperience is the experience from which a learner is pointed to RPL.
+const rplExperience = "2_to_5"
+
+// Choices returns the possible answers to q, or nil when it takes a number.
+func Choices(q Question) []string {
+	switch q {
+	case Qualification:
+		return Qualifications
+	case English:
+		return EnglishLevels
+	case Residency:
+		return ResidencyStatus
+	case Experience:
+		return ExperienceLevels
+	}
+	return nil
+}
+
+// Enabled reports whether the rules ask anything.
+func Enabled(r models.Eligibility) bool {
+	return len(Questions(r)) > 0
+}
+
+// Questions lists what to ask for the course's rules, in order. Rules with
+// values the questionnaire doesn't know are skipped, so a typo in the
+// backend never turns learners away.
+func Questions(r models.Eligibility) []Question {
+	var qs []Question
+	if r.MinAge > 0 {
+		qs = append(qs, Age)
+	}
+	if slices.Contains(Qualifications, r.Qualification) && r.Qualification != Qualifications[0] {
+		qs = append(qs, Qualification)
+	}
+	if slices.Contains(EnglishLevels, r.English) {
+		qs = append(qs, English)
+	}
+	if slices.ContainsFunc(r.Residency, func(s string) bool { return slices.Contains(ResidencyStatus, s) }) {
+		qs = append(qs, Residency)
+	}
+	if r.RPL {
+		qs = append(qs, Experience)
+	}
+	return qs
+}
+
+// Answers maps a question to the learner's answer.
+type Answers map[Question]string
+
+// ParseAnswers reads answers from URL params. Invalid answers are dropped,
+// so the question is asked again.
+func ParseAnswers(values url.Values) Answers {
+	a := Answers{}
+	for _, q := range []Question{Age, Qualification, English, Residency, Experience} {
+		v := values.Get(string(q))
+		if valid(q, v) {
+			a[q] = v
+		}
+	}
+	return a
+}
+
+func valid(q Question, v string) bool {
+	if q == Age {
+		age, err := strconv.Atoi(v)
+		return err == nil && age >= minAnswerAge && age <= maxAnswerAge
+	}
+	return slices.Contains(Choices(q), v)
+}
+
+// Values encodes the answers to the rules' questions as URL params.
+func (a Answers) Values(r models.Eligibility) url.Values {
+	v := url.Values{}
+	for _, q := range Questions(r) {
+		if answer, ok := a[q]; ok {
+			v.Set(string(q), answer)
+		}
+	}
+	return v
+}
+
+// Without returns the answers up to, but not including, q, for going back
+// to a question.
+func (a Answers) Without(r models.Eligibility, q Question) Answers {
+	kept := Answers{}
+	for _, asked := range Questions(r) {
+		if asked == q {
+			break
+		}
+		if answer, ok := a[asked]; ok {
+			kept[asked] = answer
+		}
+	}
+	return kept
+}
+
+// Next returns the first of the rules' questions not yet answered, or false
+// when they all are.
+func Next(r models.Eligibility, a Answers) (Question, bool) {
+	for _, q := range Questions(r) {
+		if _, ok := a[q]; !ok {
+			return q, true
+		}
+	}
+	return "", false
+}
+
+// Outcome is how likely the learner is to qualify.
+type Outcome string
+
+const (
+	Likely Outcome = "likely"
+	// Possible means only the qualification rule is unmet, and the learner's
+	// experience may make up for it through RPL.
+	Possible Outcome = "possible"
+	Unlikely Outcome = "unlikely"
+)
+
+// Result is the questionnaire's verdict.
+type Result struct {
+	Outcome Outcome
+	// Unmet lists the questions whose answers don't meet the rules.
+	Unmet []Question
+	// SuggestRPL is set when the course takes RPL and the learner has
+	// enough experience for it to be worth asking about.
+	SuggestRPL bool
+}
+
+// Check compares the answers with the rules. Questions left unanswered
+// count as met; the result is a guide, and an advisor has the final say.
+func Check(r models.Eligibility, a Answers) Result {
+	var res Result
+	for _, q := range Questions(r) {
+		answer, ok := a[q]
+		if ok && !meets(r, q, answer) {
+			res.Unmet = append(res.Unmet, q)
+		}
+	}
+	res.SuggestRPL = r.RPL && a[Experience] != "" && atLeast(ExperienceLevels, a[Experience], rplExperience)
+
+	switch {
+	case len(res.Unmet) == 0:
+		res.Outcome = Likely
+	case len(res.Unmet) == 1 && res.Unmet[0] == Qualification && res.SuggestRPL:
+		res.Outcome = Possible
+	default:
+		res.Outcome = Unlikely
+	}
+	return res
+}
+
+func meets(r models.Eligibility, q Question, answer string) bool {
+	switch q {
+	case Age:
+		age, _ := strconv.Atoi(answer)
+		return age >= r.MinAge
+	case Qualification:
+		return atLeast(Qualifications, answer, r.Qualification)
+	case English:
+		return atLeast(EnglishLevels, answer, r.English)
+	case Residency:
+		return slices.Contains(r.Residency, answer)
+	}
+	return true
+}
+
+// atLeast reports whether v comes no earlier than floor in the ordered
+// levels.
+func atLeast(levels []string, v, floor string) bool {
+	return slices.Index(levels, v) >= slices.Index(levels, floor)
+}
@@ -0,0 +1,160 @@
+package eligibility
+
+import (
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+)
+
+func TestCheck(t *testing.T) {
+	rules := models.Eligibility{
+		MinAge:        18,
+		Qualification: "year_12",
+		English:       "B2",
+		Residency:     []string{"citizen", "permanent_resident"},
+	}
+	rpl := rules
+	rpl.RPL = true
+
+	tests := []struct {
+		name    string
+		rules   models.Eligibility
+		answers Answers
+		want    Result
+	}{
+		{
+			name:    "all met",
+			rules:   rules,
+			answers: Answers{Age: "18", Qualification: "year_12", English: "B2", Residency: "citizen"},
+			want:    Result{Outcome: Likely},
+		},
+		{
+			name:    "above every floor",
+			rules:   rules,
+			answers: Answers{Age: "40", Qualification: "degree", English: "native", Residency: "permanent_resident"},
+			want:    Result{Outcome: Likely},
+		},
+		{
+			name:    "nothing answered",
+			rules:   rules,
+			answers: Answers{},
+			want:    Result{Outcome: Likely},
+		},
+		{
+			name:    "too young",
+			rules:   rules,
+			answers: Answers{Age: "17", Qualification: "year_12", English: "B2", Residency: "citizen"},
+			want:    Result{Outcome: Unlikely, Unmet: []Question{Age}},
+		},
+		{
+			name:    "several unmet, in question order",
+			rules:   rules,
+			answers: Answers{Age: "16", Qualification: "year_10", English: "B1", Residency: "visa"},
+			want:    Result{Outcome: Unlikely, Unmet: []Question{Age, Qualification, English, Residency}},
+		},
+		{
+			name:    "qualification unmet without RPL",
+			rules:   rules,
+			answers: Answers{Qualification: "year_10", Experience: "over_5"},
+			want:    Result{Outcome: Unlikely, Unmet: []Question{Qualification}},
+		},
+		{
+			name:    "qualification unmet with enough experience",
+			rules:   rpl,
+			answers: Answers{Qualification: "year_10", Experience: "2_to_5"},
+			want:    Result{Outcome: Possible, Unmet: []Question{Qualification}, SuggestRPL: true},
+		},
+		{
+			name:    "qualification unmet with too little experience",
+			rules:   rpl,
+			answers: Answers{Qualification: "year_10", Experience: "under_2"},
+			want:    Result{Outcome: Unlikely, Unmet: []Question{Qualification}},
+		},
+		{
+			name:    "RPL doesn't make up for other rules",
+			rules:   rpl,
+			answers: Answers{Age: "16", Qualification: "year_10", Experience: "over_5"},
+			want:    Result{Outcome: Unlikely, Unmet: []Question{Age, Qualification}, SuggestRPL: true},
+		},
+		{
+			name:    "all met, RPL still suggested",
+			rules:   rpl,
+			answers: Answers{Qualification: "diploma", Experience: "over_5"},
+			want:    Result{Outcome: Likely, SuggestRPL: true},
+		},
+		{
+			name:    "rules with unknown values are skipped",
+			rules:   models.Eligibility{Qualification: "phd", English: "fluent", Residency: []string{"martian"}},
+			answers: Answers{Qualification: "none", English: "A1", Residency: "citizen"},
+			want:    Result{Outcome: Likely},
+		},
+		{
+			name:    "no rules",
+			rules:   models.Eligibility{},
+			answers: Answers{Age: "12"},
+			want:    Result{Outcome: Likely},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Check(tt.rules, tt.answers)
+			if got.Outcome != tt.want.Outcome || !slices.Equal(got.Unmet, tt.want.Unmet) || got.SuggestRPL != tt.want.SuggestRPL {
+				t.Errorf("Check() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuestions(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules models.Eligibility
+		want  []Question
+	}{
+		{"none", models.Eligibility{}, nil},
+		{"lowest qualification asks nothing", models.Eligibility{Qualification: "none"}, nil},
+		{"residency with one known status", models.Eligibility{Residency: []string{"martian", "visa"}}, []Question{Residency}},
+		{
+			name:  "every rule",
+			rules: models.Eligibility{MinAge: 18, Qualification: "year_12", English: "B2", Residency: []string{"citizen"}, RPL: true},
+			want:  []Question{Age, Qualification, English, Residency, Experience},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Questions(tt.rules); !slices.Equal(got, tt.want) {
+				t.Errorf("Questions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAnswers(t *testing.T) {
+	values := url.Values{
+		"age":           {"9"},
+		"qualification": {"diploma"},
+		"english":       {"fluent"},
+		"residency":     {"citizen"},
+		"experience":    {""},
+	}
+	got := ParseAnswers(values)
+	want := Answers{Qualification: "diploma", Residency: "citizen"}
+	if len(got) != len(want) || got[Qualification] != want[Qualification] || got[Residency] != want[Residency] {
+		t.Errorf("ParseAnswers() = %v, want %v", got, want)
+	}
+
+	for _, age := range []string{"10", "120"} {
+		if got := ParseAnswers(url.Values{"age": {age}}); got[Age] != age {
+			t.Errorf("ParseAnswers(age=%s) = %v, want it kept", age, got)
+		}
+	}
+	for _, age := range []string{"121", "abc", "-5"} {
+		if got := ParseAnswers(url.Values{"age": {age}}); len(got) != 0 {
+			t.Errorf("ParseAnswers(age=%s) = %v, want it dropped", age, got)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Package fees works out what a course costs under each payment plan, and
+// when each payment falls due.
+package fees
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+)
+
+var (
+	ErrNoFees      = errors.New("course has no fee data")
+	ErrUnknownPlan = errors.New("unknown payment plan")
+)
+
+// Upfront is the key of paying the whole fee at once.
+const Upfront = "upfront"
+
+// Money is an amount in the currency's minor unit, e.g. cents, so splitting
+// a fee into instalments never loses or invents a cent.
+type Money int64
+
+// toMoney converts an amount in the major unit.
+func toMoney(major float64) Money {
+	return Money(math.Round(major * 100))
+}
+
+// Major returns the amount in the currency's major unit.
+func (m Money) Major() float64 {
+	return float64(m) / 100
+}
+
+// Option is a way to pay that the learner can pick.
+type Option struct {
+	Key         string
+	Frequency   string // empty when paying upfront
+	Instalments int
+	AdminFee    Money
+}
+
+// Options lists the ways to pay for a course: upfront, then each of its
+// payment plans. Plans with an unknown frequency or no instalments are left
+// out.
+func Options(f models.Fees) []Option {
+	options := []Option{{Key: Upfront}}
+	for _, p := range f.Plans {
+		if _, ok := periods[p.Frequency]; !ok || p.Instalments <= 0 {
+			continue
+		}
+		options = append(options, Option{
+			Key:         fmt.Sprintf("%s-%d", p.Frequency, p.Instalments),
+			Frequency:   p.Frequency,
+			Instalments: p.Instalments,
+			AdminFee:    toMoney(p.AdminFee),
+		})
+	}
+	return options
+}
+
+// Kinds of payment.
+const (
+	KindUpfront    = "upfront"
+	KindDeposit    = "deposit"
+	KindInstalment = "instalment"
+)
+
+// Payment is one payment in a schedule.
+type Payment struct {
+	Kind   string
+	Due    time.Time
+	Amount Money
+}
+
+// Quote is what the learner pays under one option.
+type Quote struct {
+	Currency string
+	Option   Option
+	Options  []Option
+	// Price is the upfront fee before any discount.
+	Price Money
+	// DiscountPercent comes from a running promotion, and Discount is what
+	// it takes off the price.
+	DiscountPercent float64
+	Discount        Money
+	// Total is the price less the discount plus the plan's admin fee, and
+	// always equals the sum of the payments.
+	Total    Money
+	Payments []Payment
+}
+
+// Instalment returns the regular instalment amount, or 0 when paying
+// upfront. Early instalments can be a cent more, to spread rounding.
+func (q Quote) Instalment() Money {
+	var last Money
+	for _, p := range q.Payments {
+		if p.Kind == KindInstalment {
+			last = p.Amount
+		}
+	}
+	return last
+}
+
+// Deposit returns the deposit paid before instalments start, if any.
+func (q Quote) Deposit() Money {
+	for _, p := range q.Payments {
+		if p.Kind == KindDeposit {
+			return p.Amount
+		}
+	}
+	return 0
+}
+
+// Calculate quotes the course's fees under the option with key (upfront when
+// empty), taking discountPercent off the price. The first payment is due on
+// start and instalments follow at the plan's frequency.
+func Calculate(f models.Fees, key string, discountPercent float64, start time.Time) (Quote, error) {
+	if !f.Valid() {
+		return Quote{}, ErrNoFees
+	}
+	if key == "" {
+		key = Upfront
+	}
+
+	q := Quote{
+		Currency: f.Currency,
+		Options:  Options(f),
+		Price:    toMoney(f.Upfront),
+	}
+	found := false
+	for _, o := range q.Options {
+		if o.Key == key {
+			q.Option, found = o, true
+		}
+	}
+	if !found {
+		return Quote{}, fmt.Errorf("%w %q", ErrUnknownPlan, key)
+	}
+
+	if discountPercent > 0 {
+		q.DiscountPercent = min(discountPercent, 100)
+		q.Discount = Money(math.Round(float64(q.Price) * q.DiscountPercent / 100))
+	}
+	q.Total = q.Price - q.Discount + q.Option.AdminFee
+	start = day(start)
+
+	if q.Option.Key == Upfront {
+		q.Payments = []Payment{{Kind: KindUpfront, Due: start, Amount: q.Total}}
+		return q, nil
+	}
+
+	deposit := min(toMoney(f.Deposit), q.Total)
+	if deposit > 0 {
+		q.Payments = append(q.Payments, Payment{Kind: KindDeposit, Due: start, Amount: deposit})
+	}
+	n := Money(q.Option.Instalments)
+	balance := q.Total - deposit
+	each, extra := balance/n, balance%n
+	for i := range q.Option.Instalments {
+		amount := each
+		if Money(i) < extra {
+			amount++
+		}
+		q.Payments = append(q.Payments, Payment{
+			Kind:   KindInstalment,
+			Due:    periods[q.Option.Frequency](start, i+1),
+			Amount: amount,
+		})
+	}
+	return q, nil
+}
+
+// periods give the due date of the nth instalment after start.
+var periods = map[string]func(start time.Time, n int) time.Time{
+	models.Weekly:      func(start time.Time, n int) time.Time { return start.AddDate(0, 0, 7*n) },
+	models.Fortnightly: func(start time.Time, n int) time.Time { return start.AddDate(0, 0, 14*n) },
+	models.Monthly:     addMonths,
+}
+
+// addMonths keeps to the start's day of the month, or the month's last day
+// when it is shorter: a plan starting on 31 January is next due on 28 or 29
+// February.
+func addMonths(start time.Time, n int) time.Time {
+	first := time.Date(start.Year(), start.Month()+time.Month(n), 1, 0, 0, 0, 0, start.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(start.Day(), last)-1)
+}
+
+// day truncates t to midnight in its location.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,226 @@
+package fees
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCalculate(t *testing.T) {
+	fees := models.Fees{
+		Currency: "AUD",
+		Upfront:  1000,
+		Deposit:  100,
+		Plans: []models.PaymentPlan{
+			{Frequency: models.Monthly, Instalments: 3, AdminFee: 50},
+			{Frequency: models.Weekly, Instalments: 4},
+			{Frequency: models.Fortnightly, Instalments: 2},
+			{Frequency: "yearly", Instalments: 2},
+			{Frequency: models.Monthly, Instalments: 0},
+		},
+	}
+	noDeposit := fees
+	noDeposit.Deposit = 0
+	bigDeposit := fees
+	bigDeposit.Deposit = 5000
+	start := date(2025, time.January, 31)
+
+	type payment struct {
+		kind   string
+		due    time.Time
+		amount Money
+	}
+	tests := []struct {
+		name     string
+		fees     models.Fees
+		key      string
+		discount float64
+		start    time.Time
+		total    Money
+		payments []payment
+	}{
+		{
+			name:     "upfront by default",
+			fees:     fees,
+			start:    start,
+			total:    100000,
+			payments: []payment{{KindUpfront, start, 100000}},
+		},
+		{
+			name:     "upfront with discount",
+			fees:     fees,
+			key:      Upfront,
+			discount: 12.5,
+			start:    start,
+			total:    87500,
+			payments: []payment{{KindUpfront, start, 87500}},
+		},
+		{
+			name:     "discount capped at the price",
+			fees:     fees,
+			key:      Upfront,
+			discount: 150,
+			start:    start,
+			total:    0,
+			payments: []payment{{KindUpfront, start, 0}},
+		},
+		{
+			name:  "monthly with deposit, admin fee and month ends",
+			fees:  fees,
+			key:   "monthly-3",
+			start: start,
+			// 1000 + 50 admin - 100 deposit = 950, split 316.67, 316.67, 316.66
+			total: 105000,
+			payments: []payment{
+				{KindDeposit, start, 10000},
+				{KindInstalment, date(2025, time.February, 28), 31667},
+				{KindInstalment, date(2025, time.March, 31), 31667},
+				{KindInstalment, date(2025, time.April, 30), 31666},
+			},
+		},
+		{
+			name:  "weekly without deposit",
+			fees:  noDeposit,
+			key:   "weekly-4",
+			start: date(2025, time.December, 15),
+			total: 100000,
+			payments: []payment{
+				{KindInstalment, date(2025, time.December, 22), 25000},
+				{KindInstalment, date(2025, time.December, 29), 25000},
+				{KindInstalment, date(2026, time.January, 5), 25000},
+				{KindInstalment, date(2026, time.January, 12), 25000},
+			},
+		},
+		{
+			name:     "fortnightly with discount",
+			fees:     fees,
+			key:      "fortnightly-2",
+			discount: 10,
+			start:    date(2025, time.March, 1),
+			total:    90000,
+			payments: []payment{
+				{KindDeposit, date(2025, time.March, 1), 10000},
+				{KindInstalment, date(2025, time.March, 15), 40000},
+				{KindInstalment, date(2025, time.March, 29), 40000},
+			},
+		},
+		{
+			name:  "deposit capped at the total",
+			fees:  bigDeposit,
+			key:   "weekly-4",
+			start: start,
+			total: 100000,
+			payments: []payment{
+				{KindDeposit, start, 100000},
+				{KindInstalment, date(2025, time.February, 7), 0},
+				{KindInstalment, date(2025, time.February, 14), 0},
+				{KindInstalment, date(2025, time.February, 21), 0},
+				{KindInstalment, date(2025, time.February, 28), 0},
+			},
+		},
+		{
+			name:     "start truncated to the day",
+			fees:     fees,
+			start:    time.Date(2025, time.May, 2, 17, 30, 0, 0, time.UTC),
+			total:    100000,
+			payments: []payment{{KindUpfront, date(2025, time.May, 2), 100000}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Calculate(tt.fees, tt.key, tt.discount, tt.start)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if q.Total != tt.total {
+				t.Errorf("Total = %d, want %d", q.Total, tt.total)
+			}
+			var got []payment
+			var sum Money
+			for _, p := range q.Payments {
+				got = append(got, payment{p.Kind, p.Due, p.Amount})
+				sum += p.Amount
+			}
+			if !slices.EqualFunc(got, tt.payments, func(a, b payment) bool {
+				return a.kind == b.kind && a.due.Equal(b.due) && a.amount == b.amount
+			}) {
+				t.Errorf("Payments = %v, want %v", got, tt.payments)
+			}
+			if sum != q.Total {
+				t.Errorf("payments sum to %d, want the total %d", sum, q.Total)
+			}
+		})
+	}
+}
+
+func TestCalculateOptions(t *testing.T) {
+	q, err := Calculate(models.Fees{
+		Currency: "AUD",
+		Upfront:  500,
+		Plans: []models.PaymentPlan{
+			{Frequency: models.Monthly, Instalments: 6, AdminFee: 19.99},
+			{Frequency: "quarterly", Instalments: 4},
+			{Frequency: models.Weekly, Instalments: -1},
+		},
+	}, "", 0, date(2025, time.January, 1))
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	want := []Option{
+		{Key: Upfront},
+		{Key: "monthly-6", Frequency: models.Monthly, Instalments: 6, AdminFee: 1999},
+	}
+	if !slices.Equal(q.Options, want) {
+		t.Errorf("Options = %+v, want %+v", q.Options, want)
+	}
+}
+
+func TestCalculateErrors(t *testing.T) {
+	valid := models.Fees{Currency: "AUD", Upfront: 100}
+
+	tests := []struct {
+		name string
+		fees models.Fees
+		key  string
+		want error
+	}{
+		{"no currency", models.Fees{Upfront: 100}, "", ErrNoFees},
+		{"no price", models.Fees{Currency: "AUD"}, "", ErrNoFees},
+		{"unknown plan", valid, "monthly-12", ErrUnknownPlan},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Calculate(tt.fees, tt.key, 0, time.Now()); !errors.Is(err, tt.want) {
+				t.Errorf("Calculate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteInstalmentAndDeposit(t *testing.T) {
+	q, err := Calculate(models.Fees{
+		Currency: "AUD",
+		Upfront:  100,
+		Deposit:  10,
+		Plans:    []models.PaymentPlan{{Frequency: models.Monthly, Instalments: 4}},
+	}, "monthly-4", 0, date(2025, time.January, 1))
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	// 90 over 4: 22.50 each
+	if got := q.Deposit(); got != 1000 {
+		t.Errorf("Deposit() = %d, want 1000", got)
+	}
+	if got := q.Instalment(); got != 2250 {
+		t.Errorf("Instalment() = %d, want 2250", got)
+	}
+}
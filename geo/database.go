@@ -0,0 +1,145 @@
+package geo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Database maps IP ranges to countries. It is read from a CSV file with one
+// range per line, either as "network,country" (CIDR notation, as exported
+// from MaxMind GeoLite2 Country with the locations joined in) or as
+// "first,last,country" (as in DB-IP's IP to Country Lite). Blank lines,
+// lines starting with # and a header row (the first other line, when it
+// doesn't parse) are skipped.
+type Database struct {
+	ranges []ipRange
+}
+
+type ipRange struct {
+	first, last netip.Addr
+	country     string
+}
+
+// OpenDatabase reads a database file.
+func OpenDatabase(path string) (*Database, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	db, err := ReadDatabase(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return db, nil
+}
+
+// ReadDatabase reads a database in the CSV format described on Database.
+func ReadDatabase(r io.Reader) (*Database, error) {
+	db := &Database{}
+	scanner := bufio.NewScanner(r)
+	first := true
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		header := first
+		first = false
+		fields := strings.Split(text, ",")
+		for i := range fields {
+			fields[i] = strings.Trim(strings.TrimSpace(fields[i]), `"`)
+		}
+
+		rng, err := parseRange(fields)
+		if err != nil {
+			if header {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		db.ranges = append(db.ranges, rng)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(db.ranges, func(a, b ipRange) int { return a.first.Compare(b.first) })
+	return db, nil
+}
+
+func parseRange(fields []string) (ipRange, error) {
+	switch len(fields) {
+	case 2:
+		prefix, err := netip.ParsePrefix(fields[0])
+		if err != nil {
+			return ipRange{}, err
+		}
+		prefix = prefix.Masked()
+		return newRange(prefix.Addr(), lastAddr(prefix), fields[1])
+	case 3:
+		first, err := netip.ParseAddr(fields[0])
+		if err != nil {
+			return ipRange{}, err
+		}
+		last, err := netip.ParseAddr(fields[1])
+		if err != nil {
+			return ipRange{}, err
+		}
+		return newRange(first, last, fields[2])
+	default:
+		return ipRange{}, fmt.Errorf("want 2 or 3 fields, got %d", len(fields))
+	}
+}
+
+func newRange(first, last netip.Addr, country string) (ipRange, error) {
+	first, last = first.Unmap(), last.Unmap()
+	if first.Is4() != last.Is4() || last.Less(first) {
+		return ipRange{}, fmt.Errorf("invalid range %s-%s", first, last)
+	}
+	code := normalise(country)
+	if code == "" {
+		return ipRange{}, fmt.Errorf("invalid country %q", country)
+	}
+	return ipRange{first: first, last: last, country: code}, nil
+}
+
+// lastAddr returns the highest address in prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(b)*8; bit++ {
+		b[bit/8] |= 1 << (7 - bit%8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// Lookup returns the country of ip, or "" when it isn't a valid address or
+// isn't in the database.
+func (db *Database) Lookup(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || db == nil {
+		return ""
+	}
+	addr = addr.Unmap()
+
+	// The last range starting at or before addr is the only one that can
+	// hold it, as ranges don't overlap
+	i, found := slices.BinarySearchFunc(db.ranges, addr, func(r ipRange, a netip.Addr) int { return r.first.Compare(a) })
+	if !found {
+		i--
+	}
+	if i < 0 {
+		return ""
+	}
+	if r := db.ranges[i]; r.first.Is4() == addr.Is4() && !r.last.Less(addr) {
+		return r.country
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package geo
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDatabase = `network,country
+# MaxMind-style networks
+1.0.0.0/24,AU
+1.0.1.0/24,nz
+2001:db8::/32,JP
+::ffff:5.0.0.0/120,DE
+
+# DB-IP-style ranges, quoted
+"3.0.0.0","3.0.0.255","US"
+4.0.0.0,4.0.0.0,GB
+2001:db9::,2001:db9::ffff,FR
+`
+
+func TestDatabaseLookup(t *testing.T) {
+	db, err := ReadDatabase(strings.NewReader(testDatabase))
+	if err != nil {
+		t.Fatalf("ReadDatabase() error = %v", err)
+	}
+
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{"1.0.0.0", "AU"},
+		{"1.0.0.255", "AU"},
+		{"1.0.1.0", "NZ"},
+		{"1.0.1.255", "NZ"},
+		{"1.0.2.0", ""},
+		{"0.255.255.255", ""},
+		{"3.0.0.0", "US"},
+		{"3.0.0.128", "US"},
+		{"3.0.0.255", "US"},
+		{"3.0.1.0", ""},
+		{"4.0.0.0", "GB"},
+		{"4.0.0.1", ""},
+		{"5.0.0.7", "DE"},
+		{"5.0.1.0", ""},
+		{"255.255.255.255", ""},
+		// IPv4-mapped IPv6 addresses are looked up as IPv4
+		{"::ffff:1.0.0.1", "AU"},
+		{"::ffff:3.0.0.255", "US"},
+		{"2001:db8::", "JP"},
+		{"2001:db8:ffff:ffff:ffff:ffff:ffff:ffff", "JP"},
+		{"2001:db9::", "FR"},
+		{"2001:db9::ffff", "FR"},
+		{"2001:db9::1:0", ""},
+		{"2001:db7:ffff::", ""},
+		{"::", ""},
+		// IPv6 addresses never match IPv4 ranges, even numerically below them
+		{"::1.0.0.1", ""},
+		{"", ""},
+		{"not an ip", ""},
+		{"1.0.0.0/24", ""},
+	}
+
+	for _, tt := range tests {
+		if got := db.Lookup(tt.ip); got != tt.want {
+			t.Errorf("Lookup(%q) = %q, want %q", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestDatabaseLookupNil(t *testing.T) {
+	var db *Database
+	if got := db.Lookup("1.0.0.1"); got != "" {
+		t.Errorf("nil Database Lookup() = %q, want empty", got)
+	}
+}
+
+func TestReadDatabase(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		wantErr string
+	}{
+		{name: "empty", csv: ""},
+		{name: "header only", csv: "first,last,country\n"},
+		{name: "header after a comment", csv: "# export\n\nnetwork,country\n1.0.0.0/24,AU\n"},
+		{name: "second header", csv: "network,country\nnetwork,country\n", wantErr: "line 2"},
+		{name: "bad network", csv: "1.0.0.0/24,AU\n1.0.0.0/33,NZ\n", wantErr: "line 2"},
+		{name: "reversed range", csv: "1.0.0.0/24,AU\n3.0.0.9,3.0.0.1,US\n", wantErr: "invalid range"},
+		{name: "mixed families", csv: "1.0.0.0/24,AU\n3.0.0.0,2001:db8::,US\n", wantErr: "invalid range"},
+		{name: "unknown country", csv: "1.0.0.0/24,AU\n3.0.0.0/24,XX\n", wantErr: "invalid country"},
+		{name: "too many fields", csv: "1.0.0.0/24,AU\n1,2,3,4\n", wantErr: "want 2 or 3 fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadDatabase(strings.NewReader(tt.csv))
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("ReadDatabase() error = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("ReadDatabase() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Package geo resolves the visitor's country and applies the geo-targeting
+// set on each course.
+package geo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Locator finds the country a request comes from, as an upper-case ISO
+// 3166-1 alpha-2 code, or "" when it can't tell.
+type Locator interface {
+	Country(c *gin.Context) string
+}
+
+// HeaderLocator reads the country a trusted proxy or CDN puts in a request
+// header, such as Cloudflare's CF-IPCountry.
+type HeaderLocator struct {
+	Header string
+}
+
+func (l HeaderLocator) Country(c *gin.Context) string {
+	return normalise(c.GetHeader(l.Header))
+}
+
+// DatabaseLocator looks the client IP up in a GeoIP database. The client IP
+// is taken from X-Forwarded-For when gin's trusted proxies allow it.
+type DatabaseLocator struct {
+	DB *Database
+}
+
+func (l DatabaseLocator) Country(c *gin.Context) string {
+	return l.DB.Lookup(c.ClientIP())
+}
+
+// Chain asks each locator in turn and returns the first country found.
+type Chain []Locator
+
+func (ch Chain) Country(c *gin.Context) string {
+	for _, l := range ch {
+		if country := l.Country(c); country != "" {
+			return country
+		}
+	}
+	return ""
+}
+
+// normalise upper-cases a country code, returning "" for anything that isn't
+// one. Proxies use XX for unknown and T1 for Tor, which aren't countries.
+func normalise(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 2 || code == "XX" || code == "T1" {
+		return ""
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return ""
+		}
+	}
+	return code
+}
+
+type contextKey struct{}
+
+// WithCountry returns a copy of ctx carrying the visitor's country.
+func WithCountry(ctx context.Context, country string) context.Context {
+	return context.WithValue(ctx, contextKey{}, country)
+}
+
+// FromContext returns the country stored by WithCountry, or "".
+func FromContext(ctx context.Context) string {
+	country, _ := ctx.Value(contextKey{}).(string)
+	return country
+}
@@ -0,0 +1,69 @@
+package geo
+
+import (
+	"slices"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+)
+
+// The values of graph.CourseView.GeoTargeting.
+const (
+	None        = "none"
+	Warning     = "warning"
+	Restriction = "restriction"
+)
+
+// Access is what a visitor may do with a course.
+type Access int
+
+const (
+	// Allowed courses are shown as usual.
+	Allowed Access = iota
+	// Warned courses are shown with a warning that they may not be
+	// available where the visitor is.
+	Warned
+	// Restricted courses are left out of lists and closed to enquiries.
+	Restricted
+)
+
+// Policy applies course geo-targeting for the countries the courses are
+// offered in.
+type Policy struct {
+	Countries []string
+}
+
+// Enabled reports whether the policy restricts anything.
+func (p Policy) Enabled() bool {
+	return len(p.Countries) > 0
+}
+
+// Access returns what a visitor from country may do with course. Visitors
+// whose country isn't known are treated as local, so a failed lookup never
+// hides the catalogue.
+func (p Policy) Access(course graph.CourseView, country string) Access {
+	if !p.Enabled() || country == "" || slices.Contains(p.Countries, country) {
+		return Allowed
+	}
+	switch course.GeoTargeting {
+	case Warning:
+		return Warned
+	case Restriction:
+		return Restricted
+	}
+	return Allowed
+}
+
+// Filter returns the courses that aren't restricted for country. courses is
+// never modified, as it may be a cached list.
+func (p Policy) Filter(courses []graph.CourseView, country string) []graph.CourseView {
+	if !p.Enabled() || country == "" {
+		return courses
+	}
+	kept := make([]graph.CourseView, 0, len(courses))
+	for _, c := range courses {
+		if p.Access(c, country) != Restricted {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
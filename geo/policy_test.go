@@ -0,0 +1,84 @@
+package geo
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+)
+
+func course(id int, targeting string) graph.CourseView {
+	c := graph.CourseView{GeoTargeting: targeting}
+	c.ID = id
+	return c
+}
+
+func TestPolicyAccess(t *testing.T) {
+	local := Policy{Countries: []string{"AU", "NZ"}}
+
+	tests := []struct {
+		name      string
+		policy    Policy
+		targeting string
+		country   string
+		want      Access
+	}{
+		{"restriction abroad", local, Restriction, "US", Restricted},
+		{"warning abroad", local, Warning, "US", Warned},
+		{"none abroad", local, None, "US", Allowed},
+		{"unset abroad", local, "", "US", Allowed},
+		{"unknown targeting abroad", local, "block", "US", Allowed},
+		{"restriction at home", local, Restriction, "AU", Allowed},
+		{"restriction in second country", local, Restriction, "NZ", Allowed},
+		{"unknown visitor", local, Restriction, "", Allowed},
+		{"policy disabled", Policy{}, Restriction, "US", Allowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Access(course(1, tt.targeting), tt.country); got != tt.want {
+				t.Errorf("Access() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyFilter(t *testing.T) {
+	courses := []graph.CourseView{
+		course(1, None),
+		course(2, Restriction),
+		course(3, Warning),
+		course(4, Restriction),
+	}
+	local := Policy{Countries: []string{"AU"}}
+
+	tests := []struct {
+		name    string
+		policy  Policy
+		country string
+		want    []int
+	}{
+		{"abroad", local, "US", []int{1, 3}},
+		{"at home", local, "AU", []int{1, 2, 3, 4}},
+		{"unknown visitor", local, "", []int{1, 2, 3, 4}},
+		{"policy disabled", Policy{}, "US", []int{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := slices.Clone(courses)
+			var got []int
+			for _, c := range tt.policy.Filter(courses, tt.country) {
+				got = append(got, c.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Filter() = %v, want %v", got, tt.want)
+			}
+			for i := range courses {
+				if courses[i].ID != before[i].ID {
+					t.Fatalf("Filter() modified its input")
+				}
+			}
+		})
+	}
+}
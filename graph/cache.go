@@ -0,0 +1,181 @@
+package graph
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+
+	"golang.org/x/text/language"
+)
+
+type cacheEntry struct {
+	courses    []CourseView
+	generation uint64
+	fetchedAt  time.Time
+	usedAt     time.Time
+}
+
+// maxCacheEntries bounds the cache. Tags come from request params, so
+// without a limit every new tag would keep another copy of the catalogue.
+const maxCacheEntries = 32
+
+// courseCache keeps the most recent course list per tag and locale so repeated
+// page loads don't round-trip to the GraphQL backend. Expired lists are
+// dropped, and the least recently used list makes way when it is full.
+type courseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+	// generation counts the lists stored, numbering each one so data
+	// derived from a list can tell when it has been replaced.
+	generation uint64
+}
+
+func newCourseCache(ttl time.Duration) *courseCache {
+	return &courseCache{ttl: ttl, entries: map[string]cacheEntry{}}
+}
+
+func (c *courseCache) get(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	now := time.Now()
+	if c.expired(entry, now) {
+		delete(c.entries, key)
+		return cacheEntry{}, false
+	}
+	entry.usedAt = now
+	c.entries[key] = entry
+	return entry, true
+}
+
+// set stores a list and returns its generation.
+func (c *courseCache) set(key string, courses []CourseView) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if c.expired(entry, now) {
+			delete(c.entries, k)
+		}
+	}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxCacheEntries {
+		var oldest string
+		var oldestAt time.Time
+		for k, entry := range c.entries {
+			if oldestAt.IsZero() || entry.usedAt.Before(oldestAt) {
+				oldest, oldestAt = k, entry.usedAt
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.generation++
+	c.entries[key] = cacheEntry{courses: courses, generation: c.generation, fetchedAt: now, usedAt: now}
+	return c.generation
+}
+
+func (c *courseCache) expired(entry cacheEntry, now time.Time) bool {
+	return now.Sub(entry.fetchedAt) > c.ttl
+}
+
+// loaded reports whether any list is still fresh enough to be served.
+func (c *courseCache) loaded() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for _, entry := range c.entries {
+		if !c.expired(entry, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheKey identifies a course list. Locales share the default-language entry
+// when the backend has no translations, since their content is identical.
+func (c *Client) cacheKey(tag string, locale language.Tag) string {
+	if !c.translates(locale) {
+		locale = i18n.Default
+	}
+	return locale.String() + "/" + tag
+}
+
+// locales returns the locales worth caching separately for this backend.
+func (c *Client) locales() []language.Tag {
+	if !c.translations {
+		return []language.Tag{i18n.Default}
+	}
+	return i18n.Supported()
+}
+
+// Warm fetches the given tags into the catalogue cache, in every locale the
+// backend translates. It keeps going after a failure so one bad tag doesn't
+// leave the rest cold, and returns the last error.
+func (c *Client) Warm(ctx context.Context, tags ...string) error {
+	var lastErr error
+	for _, locale := range c.locales() {
+		for _, tag := range tags {
+			courses, err := c.fetchCourses(ctx, tag, locale)
+			if err != nil {
+				log.Printf("Failed to warm cache for tag %q (%s): %v\n", tag, locale, err)
+				lastErr = err
+				continue
+			}
+			c.cache.set(c.cacheKey(tag, locale), courses)
+			for _, fn := range c.onRefresh {
+				fn(tag, locale, courses)
+			}
+		}
+	}
+	return lastErr
+}
+
+// OnRefresh registers fn to be called with every list Warm fetches, so data
+// derived from the catalogue (such as the search index) can be rebuilt
+// alongside it. Register hooks before starting RefreshLoop.
+func (c *Client) OnRefresh(fn RefreshFunc) {
+	c.onRefresh = append(c.onRefresh, fn)
+}
+
+// CacheWarmed reports whether at least one course list is cached and not yet
+// expired, so list requests can be served from it.
+func (c *Client) CacheWarmed() bool {
+	return c.cache.loaded()
+}
+
+// RefreshLoop warms the given tags immediately and then re-fetches them every
+// interval until ctx is cancelled, so cached lists never go stale while the
+// service is idle.
+func (c *Client) RefreshLoop(ctx context.Context, interval time.Duration, tags ...string) {
+	if err := c.Warm(ctx, tags...); err != nil {
+		log.Println("Cache warm-up incomplete:", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Cache refresher stopped")
+			return
+		case <-ticker.C:
+			if err := c.Warm(ctx, tags...); err != nil {
+				log.Println("Cache refresh incomplete:", err)
+			}
+		}
+	}
+}
+
+// RefreshInterval is how often RefreshLoop should run: just inside the TTL so
+// entries are replaced before they expire.
+func (c *Client) RefreshInterval() time.Duration {
+	return c.cache.ttl * 4 / 5
+}
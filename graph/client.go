@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/config"
+
+	"golang.org/x/text/language"
+)
+
+// Client talks to the courses GraphQL backend and caches course lists.
+type Client struct {
+	endpoint     string
+	anonKey      string
+	apiKey       string
+	translations bool
+	httpClient   *http.Client
+	cache        *courseCache
+	promos       promotionCache
+	onRefresh    []RefreshFunc
+}
+
+// RefreshFunc is called with each course list the cache refresher fetches.
+type RefreshFunc func(tag string, locale language.Tag, courses []CourseView)
+
+// NewClient returns a client for the given backend. Course lists are cached
+// for cacheTTL.
+func NewClient(backend config.Backend, cacheTTL time.Duration) *Client {
+	return &Client{
+		endpoint:     backend.GraphQLURL,
+		anonKey:      string(backend.AnonKey),
+		apiKey:       string(backend.APIKey),
+		translations: backend.Translations,
+		httpClient:   &http.Client{Timeout: 15 * time.Second},
+		cache:        newCourseCache(cacheTTL),
+	}
+}
+
+// newRequest prepares a POST to the GraphQL endpoint with our API keys set.
+func (c *Client) newRequest(ctx context.Context, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("apikey", c.anonKey)
+	req.Header.Set("ss-api-key", c.apiKey)
+	return req, nil
+}
@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+)
+
+// decodeColumn decodes a structured JSON column into v. Like the other JSON
+// columns it may arrive as an object or as a string holding one. Empty
+// columns leave v alone, and malformed ones are logged and reported as false.
+func decodeColumn(name string, raw json.RawMessage, v any) bool {
+	if len(raw) == 0 || string(raw) == "null" {
+		return false
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		if s == "" {
+			return false
+		}
+		raw = []byte(s)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		log.Printf("Failed to parse %s: %v\n", name, err)
+		return false
+	}
+	return true
+}
+
+// parseFees decodes the fees column.
+func parseFees(raw json.RawMessage) models.Fees {
+	var fees models.Fees
+	if !decodeColumn("fees", raw, &fees) {
+		return models.Fees{}
+	}
+	return fees
+}
+
+// parseBrandTheme decodes the brand's theme column.
+func parseBrandTheme(raw json.RawMessage) map[string]string {
+	var tokens map[string]string
+	if !decodeColumn("brand theme", raw, &tokens) {
+		return nil
+	}
+	return tokens
+}
+
+// parseEligibility decodes the eligibility column.
+func parseEligibility(raw json.RawMessage) models.Eligibility {
+	var rules models.Eligibility
+	if !decodeColumn("eligibility", raw, &rules) {
+		return models.Eligibility{}
+	}
+	return rules
+}
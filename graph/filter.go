@@ -0,0 +1,52 @@
+package graph
+
+import "slices"
+
+// CourseFilter narrows a course list by its multi-valued fields. A course
+// matches when, for every non-empty field, it has at least one of the listed
+// values.
+type CourseFilter struct {
+	Level     []string
+	Delivery  []string
+	Locations []string
+	Frequency []string
+}
+
+// IsZero reports whether the filter selects nothing, i.e. matches everything.
+func (f CourseFilter) IsZero() bool {
+	return len(f.Level) == 0 && len(f.Delivery) == 0 && len(f.Locations) == 0 && len(f.Frequency) == 0
+}
+
+// Matches reports whether course passes the filter.
+func (f CourseFilter) Matches(course CourseView) bool {
+	return overlaps(f.Level, course.Level) &&
+		overlaps(f.Delivery, course.Delivery) &&
+		overlaps(f.Locations, course.Locations) &&
+		overlaps(f.Frequency, course.Frequency)
+}
+
+// Apply returns the courses passing the filter, without modifying the input.
+func (f CourseFilter) Apply(courses []CourseView) []CourseView {
+	if f.IsZero() {
+		return courses
+	}
+	var matched []CourseView
+	for _, course := range courses {
+		if f.Matches(course) {
+			matched = append(matched, course)
+		}
+	}
+	return matched
+}
+
+func overlaps(wanted, have []string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	for _, v := range have {
+		if slices.Contains(wanted, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,16 +1,15 @@
 package graph
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
-	"net/http"
-	"os"
 	"strings"
 
 	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/text/language"
 )
 
 type TestimonialEntry struct {
@@ -19,10 +18,12 @@ type TestimonialEntry struct {
 	Testimonial string `json:"testimonial"`
 }
 
-func GetCourseByID(id int) (CourseView, error) {
-	// 1. Build our GraphQL query with a $id variable
-	query := `
-		query($id: Int!) {
+// GetCourseByID fetches a single course with its copy in the given locale.
+func (c *Client) GetCourseByID(ctx context.Context, id int, locale language.Tag) (CourseView, error) {
+	// 1. Build our GraphQL query with a $id variable (and $locale when translated)
+	variables := map[string]interface{}{"id": id}
+	query := c.localeQuery(`
+		query%s {
 			api_v1_coursesCollection(filter: { id: { eq: $id } }) {
 				edges {
 					cursor
@@ -64,6 +65,8 @@ func GetCourseByID(id int) (CourseView, error) {
 							logo
 						}
 						testimonies
+						fees
+						eligibility
 						brand_id
 						brand {
 							id
@@ -71,17 +74,18 @@ func GetCourseByID(id int) (CourseView, error) {
 							about_provider
 							logo
 							rto_code
-						}
+							theme
+						}%s
 					}
 				}
 			}
 		}
-	`
+	`, []string{"$id: Int!"}, locale, variables)
 
 	// 2. Marshal the request body with query + variables
 	payload := map[string]interface{}{
 		"query":     query,
-		"variables": map[string]int{"id": id},
+		"variables": variables,
 	}
 	reqBody, err := json.Marshal(payload)
 	if err != nil {
@@ -90,18 +94,14 @@ func GetCourseByID(id int) (CourseView, error) {
 	}
 
 	// 3. Prepare the HTTP request
-	req, err := http.NewRequest("POST", os.Getenv("SS_GRAPHQL"), bytes.NewBuffer(reqBody))
+	req, err := c.newRequest(ctx, reqBody)
 	if err != nil {
 		log.Println("Failed to create request:", err)
 		return CourseView{}, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("apikey", os.Getenv("SS_ANON_KEY"))
-	req.Header.Set("ss-api-key", os.Getenv("SS_API_KEY"))
 
 	// 4. Execute the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		log.Println("Failed to send request:", err)
 		return CourseView{}, err
@@ -137,6 +137,7 @@ func GetCourseByID(id int) (CourseView, error) {
 
 	// 8. Extract the first node
 	course := wrapper.Data.APIV1CoursesCollection.Edges[0].Node
+	localise(&course, locale)
 
 	// 9. Convert []graphql.String → []string, then join with commas
 	var deliveryVals []string
@@ -171,6 +172,9 @@ func GetCourseByID(id int) (CourseView, error) {
 	// Convert ID to string
 	idText := fmt.Sprint(course.ID)
 
+	// Parse the course length and its intake dates
+	duration := parseDuration(course.DurationLength, course.DurationUnit)
+
 	// Format Geo Targeting
 	formattedGeoTargeting := formatValue("geo", string(course.GeoTargeting))
 
@@ -180,6 +184,12 @@ func GetCourseByID(id int) (CourseView, error) {
 		DeliveryText: deliveryText,
 		TestimonialText: testimonialText,
 		GeoTargeting: formattedGeoTargeting,
+		Fees: parseFees(course.Fees),
+		Eligibility: parseEligibility(course.Eligibility),
+		BrandTheme: parseBrandTheme(course.Brand.Theme),
+		Modules: parseModules(course.ID, course.CourseModule),
+		Duration: duration,
+		Intakes: parseIntakes(course.StartDate, duration),
 		DeliveryLongText: safeHTML(course.DeliveryLongText),
 		Overview: safeHTML(course.Overview),
 		WhoIsItFor: safeHTML(course.WhoIsItFor),
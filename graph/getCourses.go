@@ -1,16 +1,18 @@
 package graph
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
-	"os"
 	"strings"
 
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
 	"github.com/Tonnie-Exelero/go-ms-kit/models" // Adjust the import path as necessary
+
+	"golang.org/x/text/language"
 )
 
 type CourseView struct {
@@ -36,6 +38,12 @@ type CourseView struct {
 	PaymentOptions                   string
 	AdditionalInformation            string
 	GeoTargeting                     string
+	Fees                             models.Fees
+	Eligibility                      models.Eligibility
+	BrandTheme                       map[string]string
+	Modules                          []models.Module
+	Duration                         models.Duration
+	Intakes                          []models.Intake
 }
 
 type GraphQLResponse struct {
@@ -49,29 +57,60 @@ type GraphQLResponse struct {
 	} `json:"data"`
 }
 
+// dataMap normalises backend codes that drive behaviour rather than display.
+// Display labels for delivery and frequency live in the i18n catalogues.
 var dataMap = map[string]map[string]string{
-    "delivery": {
-        "in_class": "In Class",
-        "blended":  "Blended",
-        "online":   "Online",
-        "virtual":  "Virtual",
-    },
-    "frequency": {
-        "SELF_PACED": "Self Paced",
-		"FULL_TIME":  "Full Time", 
-		"PART_TIME":  "Part Time",
-    },
 	"geo": {
-        "NONE":        "none",
+		"NONE":        "none",
 		"WARNING":     "warning",
-		"RESTRICTION": "restriction", 
-    },
+		"RESTRICTION": "restriction",
+	},
+}
+
+// GetCourses returns the courses for a tag in the given locale, served from
+// the catalogue cache when a fresh copy is available.
+func (c *Client) GetCourses(ctx context.Context, tagFilter string, locale language.Tag) ([]CourseView, error) {
+	key := c.cacheKey(tagFilter, locale)
+	if entry, ok := c.cache.get(key); ok {
+		return entry.courses, nil
+	}
+
+	courses, err := c.fetchCourses(ctx, tagFilter, locale)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(key, courses)
+	return courses, nil
+}
+
+// CourseList is an unfiltered course list and the generation of the cache
+// entry holding it. A list with the same client, tag, locale and generation
+// holds the same courses, so data derived from it can be cached under them.
+type CourseList struct {
+	Courses    []CourseView
+	Generation uint64
 }
 
-func GetCourses(tagFilter string) ([]CourseView, error) {
+// GetCourseList returns the unfiltered courses for a tag in the given locale
+// along with their cache generation.
+func (c *Client) GetCourseList(ctx context.Context, tagFilter string, locale language.Tag) (CourseList, error) {
+	key := c.cacheKey(tagFilter, locale)
+	if entry, ok := c.cache.get(key); ok {
+		return CourseList{Courses: entry.courses, Generation: entry.generation}, nil
+	}
+
+	courses, err := c.fetchCourses(ctx, tagFilter, locale)
+	if err != nil {
+		return CourseList{}, err
+	}
+	return CourseList{Courses: courses, Generation: c.cache.set(key, courses)}, nil
+}
+
+func (c *Client) fetchCourses(ctx context.Context, tagFilter string, locale language.Tag) ([]CourseView, error) {
 	// 1. Build GraphQL query payload
-	query := `
-		query {
+	variables := map[string]interface{}{}
+	query := c.localeQuery(`
+		query%s {
 			api_v1_coursesCollection {
 				edges {
 					cursor
@@ -120,16 +159,19 @@ func GetCourses(tagFilter string) ([]CourseView, error) {
 							about_provider
 							logo
 							rto_code
-						}
+						}%s
 					}
 				}
 			}
 		}
-	`
+	`, nil, locale, variables)
 
-	requestBody := map[string]string{
+	requestBody := map[string]interface{}{
 		"query": query,
 	}
+	if len(variables) > 0 {
+		requestBody["variables"] = variables
+	}
 	jsonData, err := json.Marshal(requestBody)
 	if err != nil {
 		log.Println("Failed to marshal request body:", err)
@@ -137,17 +179,13 @@ func GetCourses(tagFilter string) ([]CourseView, error) {
 	}
 
 	// 2. Send HTTP request
-	req, err := http.NewRequest("POST", os.Getenv("SS_GRAPHQL"), bytes.NewBuffer(jsonData))
+	req, err := c.newRequest(ctx, jsonData)
 	if err != nil {
 		log.Println("Failed to create request:", err)
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("apikey", os.Getenv("SS_ANON_KEY"))
-	req.Header.Set("ss-api-key", os.Getenv("SS_API_KEY"))	
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		log.Println("Failed to send request:", err)
 		return nil, err
@@ -187,11 +225,12 @@ func GetCourses(tagFilter string) ([]CourseView, error) {
 	// 4. Flatten edges into view objects & join Delivery slice
 	var result []CourseView
 	for _, edge := range response.Data.APIV1CoursesCollection.Edges {
-		c := edge.Node
+		course := edge.Node
+		localise(&course, locale)
 
 		// Convert and format Delivery values
 		var dvals []string
-		for _, gs := range c.Delivery {
+		for _, gs := range course.Delivery {
 			formatted := formatValue("delivery", string(gs))
 			dvals = append(dvals, formatted)
 		}
@@ -199,30 +238,38 @@ func GetCourses(tagFilter string) ([]CourseView, error) {
 
 		// Convert and format Frequency values
 		var freq []string
-		for _, gs := range c.Frequency {
+		for _, gs := range course.Frequency {
 			formatted := formatValue("frequency", string(gs))
 			freq = append(freq, formatted)
 		}
 		freqtext := strings.Join(freq, ", ")
 
 		// Convert ID to string
-		idText := fmt.Sprint(c.ID)
+		idText := fmt.Sprint(course.ID)
+
+		// Parse the course length and its intake dates
+		duration := parseDuration(course.DurationLength, course.DurationUnit)
 
 		// Append our view
 		result = append(result, CourseView{
-			Course:        c,
+			Course:        course,
 			IDText:        idText,
 			DeliveryText:  dtext,
 			FrequencyText: freqtext,
-			Overview: safeHTML(c.Overview),
-			JobOutcomes: safeHTML(c.JobOutcomes),
+			GeoTargeting:  formatValue("geo", course.GeoTargeting),
+			Duration:      duration,
+			Intakes:       parseIntakes(course.StartDate, duration),
+			Modules:       parseModules(course.ID, course.CourseModule),
+			Overview: safeHTML(course.Overview),
+			JobOutcomes: safeHTML(course.JobOutcomes),
 		})
 	}
 
 	return result, nil
 }
 
-// Format data to human readable
+// Format data to human readable, using the default locale's labels. Templates
+// relabel per request with i18n.Values.
 func formatValue(category, value string) string {
     if mappings, ok := dataMap[category]; ok {
         // Exact case-sensitive lookup
@@ -230,5 +277,5 @@ func formatValue(category, value string) string {
             return formatted
         }
     }
-    return value
-}
\ No newline at end of file
+    return i18n.ValueIn(i18n.Default, category, value)
+}
@@ -0,0 +1,148 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+)
+
+type promotionsResponse struct {
+	Data struct {
+		APIV1PromotionsCollection struct {
+			Edges []struct {
+				Node models.Promotion `json:"node"`
+			} `json:"edges"`
+		} `json:"api_v1_promotionsCollection"`
+	} `json:"data"`
+}
+
+// promotionRetry is how long a failed fetch is remembered before the backend
+// is asked again, so an outage isn't hit by every detail view.
+const promotionRetry = 30 * time.Second
+
+// promotionCache holds the last promotions fetched from the backend.
+type promotionCache struct {
+	mu        sync.Mutex
+	promos    []models.Promotion
+	fetchedAt time.Time     // last attempt, failed or not
+	failed    bool          // whether the last attempt failed
+	fetching  chan struct{} // closed when the fetch in flight finishes
+	err       error         // the in-flight fetch's error, for its waiters
+}
+
+// GetPromotions returns the promotions stored in the backend, cached for the
+// client's cache TTL. Concurrent callers share one fetch, made without
+// holding the lock. After a failure the last promotions are served until
+// promotionRetry has passed.
+func (c *Client) GetPromotions(ctx context.Context) ([]models.Promotion, error) {
+	pc := &c.promos
+	pc.mu.Lock()
+	if !pc.fetchedAt.IsZero() && time.Since(pc.fetchedAt) < pc.maxAge(c.cache.ttl) {
+		defer pc.mu.Unlock()
+		return pc.promos, nil
+	}
+	if wait := pc.fetching; wait != nil {
+		pc.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		pc.mu.Lock()
+		defer pc.mu.Unlock()
+		return pc.promos, pc.err
+	}
+	done := make(chan struct{})
+	pc.fetching = done
+	pc.mu.Unlock()
+
+	// Waiters share the result, so don't let this request's cancellation
+	// fail it for them; the HTTP client's timeout still applies.
+	promos, err := c.fetchPromotions(context.WithoutCancel(ctx))
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.fetchedAt, pc.failed, pc.err = time.Now(), err != nil, err
+	if err == nil {
+		pc.promos = promos
+	}
+	pc.fetching = nil
+	close(done)
+	return pc.promos, err
+}
+
+// maxAge is how long the last attempt stands: the TTL after a success, and
+// promotionRetry (at most the TTL) after a failure.
+func (pc *promotionCache) maxAge(ttl time.Duration) time.Duration {
+	if pc.failed {
+		return min(promotionRetry, ttl)
+	}
+	return ttl
+}
+
+// fetchPromotions asks the backend for every promotion.
+func (c *Client) fetchPromotions(ctx context.Context) ([]models.Promotion, error) {
+	query := `
+		query {
+			api_v1_promotionsCollection {
+				edges {
+					node {
+						id
+						kind
+						text
+						priority
+						starts_at
+						ends_at
+						course_ids
+						brand_ids
+						tenants
+						levels
+						delivery
+						discount
+					}
+				}
+			}
+		}
+	`
+	reqBody, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		log.Println("Failed to fetch promotions:", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+
+	var wrapper promotionsResponse
+	if err := json.Unmarshal(body, &wrapper); err != nil {
+		return nil, fmt.Errorf("error decoding JSON: %w", err)
+	}
+
+	var promos []models.Promotion
+	for _, edge := range wrapper.Data.APIV1PromotionsCollection.Edges {
+		promos = append(promos, edge.Node)
+	}
+	return promos, nil
+}
@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// unitPolicy sanitises unit details. It is built once, as course lists
+// decode every course's modules.
+var unitPolicy = bluemonday.UGCPolicy()
+
+// moduleItem is an entry of course_module. The column holds either a tree,
+// a list of modules each with its units:
+//
+//	[{"module": "Core", "electives": 0, "units": [{"code": "BSBOPS504", "name": "...", "nominal_hours": 40, "type": "core", "details": "<p>...</p>"}]}]
+//
+// or the older flat list, where an item with a module name starts a new
+// module and is also its first unit:
+//
+//	[{"module": "Core", "code": "BSBOPS504", "name": "...", "details": "..."}, {"code": "...", "name": "..."}]
+type moduleItem struct {
+	Module       string          `json:"module"`
+	Electives    int             `json:"electives"`
+	Units        []moduleItem    `json:"units"`
+	Code         string          `json:"code"`
+	Name         string          `json:"name"`
+	NominalHours float64         `json:"nominal_hours"`
+	Type         models.UnitType `json:"type"`
+	Details      string          `json:"details"`
+}
+
+// parseModules decodes the course_module column, logging whatever had to be
+// dropped or corrected to pass validation.
+func parseModules(courseID int, raw json.RawMessage) []models.Module {
+	var items []moduleItem
+	if !decodeColumn("course_module", raw, &items) {
+		return nil
+	}
+	modules, err := buildModules(items)
+	if err != nil {
+		log.Printf("Course %d course_module: %v\n", courseID, err)
+	}
+	return modules
+}
+
+// buildModules turns course_module items into modules, in either format,
+// and validates them:
+//
+//   - units need a name, and codes must be unique within the course;
+//   - nominal hours can't be negative;
+//   - types are core or elective, core when missing;
+//   - the electives to pick are between 0 and the module's elective units;
+//   - modules without units are left out.
+//
+// Invalid units are dropped and other problems corrected, and each is
+// reported in the returned error.
+func buildModules(items []moduleItem) ([]models.Module, error) {
+	var tree []moduleItem
+	if isTree(items) {
+		tree = items
+	} else {
+		tree = nestFlat(items)
+	}
+
+	var errs []error
+	codes := map[string]bool{}
+	var modules []models.Module
+	for i, item := range tree {
+		where := fmt.Sprintf("module %d", i+1)
+		if item.Module != "" {
+			where = fmt.Sprintf("module %q", item.Module)
+		}
+
+		m := models.Module{Name: strings.TrimSpace(item.Module)}
+		for j, raw := range item.Units {
+			u := models.Unit{
+				Code:         strings.TrimSpace(raw.Code),
+				Name:         strings.TrimSpace(raw.Name),
+				NominalHours: raw.NominalHours,
+				Type:         raw.Type,
+				Details:      unitPolicy.Sanitize(raw.Details),
+			}
+			switch {
+			case u.Name == "":
+				errs = append(errs, fmt.Errorf("%s: unit %d has no name", where, j+1))
+				continue
+			case u.Code != "" && codes[u.Code]:
+				errs = append(errs, fmt.Errorf("%s: duplicate unit code %s", where, u.Code))
+				continue
+			}
+			codes[u.Code] = u.Code != ""
+
+			if u.NominalHours < 0 {
+				errs = append(errs, fmt.Errorf("%s: unit %q has negative nominal hours", where, u.Name))
+				u.NominalHours = 0
+			}
+			switch u.Type {
+			case models.Core, models.Elective:
+			case "":
+				u.Type = models.Core
+			default:
+				errs = append(errs, fmt.Errorf("%s: unit %q has unknown type %q", where, u.Name, u.Type))
+				u.Type = models.Core
+			}
+			m.Units = append(m.Units, u)
+		}
+
+		if len(m.Units) == 0 {
+			errs = append(errs, fmt.Errorf("%s has no units", where))
+			continue
+		}
+		m.Electives = item.Electives
+		if n := m.ElectiveUnits(); m.Electives < 0 || m.Electives > n {
+			errs = append(errs, fmt.Errorf("%s: %d electives to pick from %d elective units", where, m.Electives, n))
+			m.Electives = max(0, min(m.Electives, n))
+		}
+		modules = append(modules, m)
+	}
+	return modules, errors.Join(errs...)
+}
+
+// isTree reports whether the items are in the tree format.
+func isTree(items []moduleItem) bool {
+	for _, item := range items {
+		if item.Units != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// nestFlat groups flat items into modules. Items before the first module
+// name are kept in an unnamed module rather than dropped.
+func nestFlat(items []moduleItem) []moduleItem {
+	var tree []moduleItem
+	for _, item := range items {
+		if item.Module != "" || len(tree) == 0 {
+			tree = append(tree, moduleItem{Module: item.Module})
+		}
+		unit := item
+		unit.Module = ""
+		tree[len(tree)-1].Units = append(tree[len(tree)-1].Units, unit)
+	}
+	return tree
+}
@@ -0,0 +1,200 @@
+package graph
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+)
+
+func TestBuildModules(t *testing.T) {
+	tests := []struct {
+		name    string
+		column  string
+		want    []models.Module
+		wantErr []string
+	}{
+		{
+			name: "tree",
+			column: `[
+				{"module": "Core", "units": [
+					{"code": "BSB1", "name": "Plan", "nominal_hours": 40, "details": "<p>Plan work</p>"},
+					{"code": "BSB2", "name": "Lead", "type": "core"}
+				]},
+				{"module": "Electives", "electives": 1, "units": [
+					{"code": "BSB3", "name": "Market", "type": "elective"},
+					{"code": "BSB4", "name": "Sell", "type": "elective"}
+				]}
+			]`,
+			want: []models.Module{
+				{Name: "Core", Units: []models.Unit{
+					{Code: "BSB1", Name: "Plan", NominalHours: 40, Type: models.Core, Details: "<p>Plan work</p>"},
+					{Code: "BSB2", Name: "Lead", Type: models.Core},
+				}},
+				{Name: "Electives", Electives: 1, Units: []models.Unit{
+					{Code: "BSB3", Name: "Market", Type: models.Elective},
+					{Code: "BSB4", Name: "Sell", Type: models.Elective},
+				}},
+			},
+		},
+		{
+			name: "flat list",
+			column: `[
+				{"module": "Core", "code": "BSB1", "name": "Plan"},
+				{"code": "BSB2", "name": "Lead"},
+				{"module": "Extra", "code": "BSB3", "name": "Market"}
+			]`,
+			want: []models.Module{
+				{Name: "Core", Units: []models.Unit{
+					{Code: "BSB1", Name: "Plan", Type: models.Core},
+					{Code: "BSB2", Name: "Lead", Type: models.Core},
+				}},
+				{Name: "Extra", Units: []models.Unit{{Code: "BSB3", Name: "Market", Type: models.Core}}},
+			},
+		},
+		{
+			name:   "flat list before the first module name",
+			column: `[{"name": "Induction"}, {"module": "Core", "name": "Plan"}]`,
+			want: []models.Module{
+				{Units: []models.Unit{{Name: "Induction", Type: models.Core}}},
+				{Name: "Core", Units: []models.Unit{{Name: "Plan", Type: models.Core}}},
+			},
+		},
+		{
+			name:   "codes with slashes and spaces are kept",
+			column: `[{"module": " Core ", "units": [{"code": " CHC/30121 ", "name": " Care "}]}]`,
+			want: []models.Module{
+				{Name: "Core", Units: []models.Unit{{Code: "CHC/30121", Name: "Care", Type: models.Core}}},
+			},
+		},
+		{
+			name: "invalid units dropped",
+			column: `[{"module": "Core", "units": [
+				{"code": "BSB1", "name": "Plan"},
+				{"code": "BSB2", "name": " "},
+				{"code": "BSB1", "name": "Plan again"},
+				{"name": "No code"},
+				{"name": "No code either"}
+			]}]`,
+			want: []models.Module{
+				{Name: "Core", Units: []models.Unit{
+					{Code: "BSB1", Name: "Plan", Type: models.Core},
+					{Name: "No code", Type: models.Core},
+					{Name: "No code either", Type: models.Core},
+				}},
+			},
+			wantErr: []string{`module "Core": unit 2 has no name`, `module "Core": duplicate unit code BSB1`},
+		},
+		{
+			name: "duplicate codes across modules",
+			column: `[
+				{"module": "A", "units": [{"code": "BSB1", "name": "Plan"}]},
+				{"module": "B", "units": [{"code": "BSB1", "name": "Plan"}, {"code": "BSB2", "name": "Lead"}]}
+			]`,
+			want: []models.Module{
+				{Name: "A", Units: []models.Unit{{Code: "BSB1", Name: "Plan", Type: models.Core}}},
+				{Name: "B", Units: []models.Unit{{Code: "BSB2", Name: "Lead", Type: models.Core}}},
+			},
+			wantErr: []string{`module "B": duplicate unit code BSB1`},
+		},
+		{
+			name:   "corrected hours and type",
+			column: `[{"module": "Core", "units": [{"name": "Plan", "nominal_hours": -5, "type": "optional"}]}]`,
+			want: []models.Module{
+				{Name: "Core", Units: []models.Unit{{Name: "Plan", Type: models.Core}}},
+			},
+			wantErr: []string{`unit "Plan" has negative nominal hours`, `unit "Plan" has unknown type "optional"`},
+		},
+		{
+			name: "electives clamped",
+			column: `[
+				{"module": "Many", "electives": 5, "units": [{"name": "A", "type": "elective"}, {"name": "B", "type": "elective"}]},
+				{"module": "Negative", "electives": -1, "units": [{"name": "C", "type": "elective"}]},
+				{"module": "Core only", "electives": 1, "units": [{"name": "D"}]}
+			]`,
+			want: []models.Module{
+				{Name: "Many", Electives: 2, Units: []models.Unit{{Name: "A", Type: models.Elective}, {Name: "B", Type: models.Elective}}},
+				{Name: "Negative", Units: []models.Unit{{Name: "C", Type: models.Elective}}},
+				{Name: "Core only", Units: []models.Unit{{Name: "D", Type: models.Core}}},
+			},
+			wantErr: []string{
+				`module "Many": 5 electives to pick from 2 elective units`,
+				`module "Negative": -1 electives to pick from 1 elective units`,
+				`module "Core only": 1 electives to pick from 0 elective units`,
+			},
+		},
+		{
+			name:    "modules without units dropped",
+			column:  `[{"module": "Empty", "units": []}, {"units": [{"name": ""}]}, {"module": "Core", "units": [{"name": "Plan"}]}]`,
+			want:    []models.Module{{Name: "Core", Units: []models.Unit{{Name: "Plan", Type: models.Core}}}},
+			wantErr: []string{`module "Empty" has no units`, `module 2: unit 1 has no name`, `module 2 has no units`},
+		},
+		{
+			name:   "details sanitised",
+			column: `[{"module": "Core", "units": [{"name": "Plan", "details": "<p onclick=\"x()\">Hi</p><script>alert(1)</script>"}]}]`,
+			want: []models.Module{
+				{Name: "Core", Units: []models.Unit{{Name: "Plan", Type: models.Core, Details: "<p>Hi</p>"}}},
+			},
+		},
+		{
+			name:   "empty",
+			column: `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var items []moduleItem
+			if err := json.Unmarshal([]byte(tt.column), &items); err != nil {
+				t.Fatalf("bad test column: %v", err)
+			}
+			got, err := buildModules(items)
+
+			if !equalModules(got, tt.want) {
+				t.Errorf("buildModules() = %+v, want %+v", got, tt.want)
+			}
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("buildModules() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("buildModules() error = nil, want %q", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("buildModules() error = %q, want it to mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func equalModules(a, b []models.Module) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || a[i].Electives != b[i].Electives || len(a[i].Units) != len(b[i].Units) {
+			return false
+		}
+		for j := range a[i].Units {
+			if a[i].Units[j] != b[i].Units[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestParseModulesStringColumn(t *testing.T) {
+	// The backend may send the column as a string holding the JSON
+	raw, _ := json.Marshal(`[{"module": "Core", "units": [{"code": "BSB1", "name": "Plan"}]}]`)
+	got := parseModules(1, raw)
+	want := []models.Module{{Name: "Core", Units: []models.Unit{{Code: "BSB1", Name: "Plan", Type: models.Core}}}}
+	if !equalModules(got, want) {
+		t.Errorf("parseModules() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Ping sends a trivial query to the GraphQL backend to confirm it is reachable
+// and accepting our API keys.
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := c.newRequest(ctx, []byte(`{"query":"{ __typename }"}`))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+	return nil
+}
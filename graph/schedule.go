@@ -0,0 +1,125 @@
+package graph
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+)
+
+// parseDuration normalises the free-text length and unit from the backend,
+// accepting plurals and any case ("Months", "weeks").
+func parseDuration(length, unit string) models.Duration {
+	d := models.Duration{Raw: strings.TrimSpace(length + " " + unit)}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(length), 64)
+	if err != nil || n <= 0 {
+		return d
+	}
+
+	switch u := models.DurationUnit(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(unit)), "s")); u {
+	case models.Hour, models.Day, models.Week, models.Month, models.Year:
+		d.Length, d.Unit = n, u
+	}
+	return d
+}
+
+// parseIntakes reads every start date out of the backend's start_date field,
+// which holds either a single date, a list separated by commas, semicolons or
+// newlines, or a JSON array of dates. Unparseable entries are skipped and the
+// result is sorted and de-duplicated.
+func parseIntakes(raw string, duration models.Duration) []models.Intake {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+
+	var parts []string
+	if err := json.Unmarshal([]byte(raw), &parts); err != nil {
+		// A single date such as "2 January 2006" may itself be the whole value
+		if _, ok := i18n.ParseDate(raw); ok {
+			parts = []string{raw}
+		} else {
+			parts = strings.FieldsFunc(raw, func(r rune) bool {
+				return r == ',' || r == ';' || r == '\n' || r == '|'
+			})
+		}
+	}
+
+	var intakes []models.Intake
+	for _, p := range parts {
+		start, ok := i18n.ParseDate(p)
+		if !ok {
+			continue
+		}
+		intake := models.Intake{Start: start}
+		if duration.Valid() {
+			intake.End = duration.After(start)
+		}
+		intakes = append(intakes, intake)
+	}
+
+	slices.SortFunc(intakes, func(a, b models.Intake) int { return a.Start.Compare(b.Start) })
+	return slices.CompactFunc(intakes, func(a, b models.Intake) bool { return a.Start.Equal(b.Start) })
+}
+
+// UpcomingIntakes returns the intakes starting on or after today.
+func (v CourseView) UpcomingIntakes(now time.Time) []models.Intake {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	var upcoming []models.Intake
+	for _, intake := range v.Intakes {
+		if !intake.Start.Before(today) {
+			upcoming = append(upcoming, intake)
+		}
+	}
+	return upcoming
+}
+
+// NextIntake returns the first upcoming intake, if any.
+func (v CourseView) NextIntake(now time.Time) (models.Intake, bool) {
+	upcoming := v.UpcomingIntakes(now)
+	if len(upcoming) == 0 {
+		return models.Intake{}, false
+	}
+	return upcoming[0], true
+}
+
+// SortByNextIntake returns the courses ordered by their next start date.
+// Courses without an upcoming intake go last, in their original order. The
+// input is left untouched since it usually comes from the cache.
+func SortByNextIntake(courses []CourseView, now time.Time) []CourseView {
+	sorted := slices.Clone(courses)
+	slices.SortStableFunc(sorted, func(a, b CourseView) int {
+		ai, aok := a.NextIntake(now)
+		bi, bok := b.NextIntake(now)
+		switch {
+		case aok && bok:
+			return ai.Start.Compare(bi.Start)
+		case aok:
+			return -1
+		case bok:
+			return 1
+		}
+		return 0
+	})
+	return sorted
+}
+
+// StartingBetween returns the courses with an upcoming intake in [from, to].
+// A zero bound leaves that side open.
+func StartingBetween(courses []CourseView, from, to, now time.Time) []CourseView {
+	var matched []CourseView
+	for _, course := range courses {
+		for _, intake := range course.UpcomingIntakes(now) {
+			if (from.IsZero() || !intake.Start.Before(from)) && (to.IsZero() || !intake.Start.After(to)) {
+				matched = append(matched, course)
+				break
+			}
+		}
+	}
+	return matched
+}
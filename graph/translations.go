@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+
+	"golang.org/x/text/language"
+)
+
+// translationSelection asks for the localised copy of each course. It is only
+// added to queries when the backend serves translations and a non-default
+// locale is requested, so backends without the table keep working.
+const translationSelection = `
+						translations: course_translationsCollection(filter: { locale: { eq: $locale } }) {
+							edges {
+								node {
+									locale
+									course_name
+									delivery_long_text
+									overview
+									who_is_it_for
+									what_youll_learn
+									duration_and_study_load
+									job_outcomes
+									entry_requirements
+									course_features
+									work_placement
+									recognition_of_prior_learning
+									assessment
+									further_study_and_education_pathways
+									professional_recognition
+									materials
+									payment_options
+									additional_information
+									course_module
+									top_panel
+								}
+							}
+						}`
+
+// translates reports whether requests for locale should ask for translations.
+func (c *Client) translates(locale language.Tag) bool {
+	return c.translations && locale != i18n.Default
+}
+
+// localeQuery fills the variable declarations and translation selection into
+// query, which must carry two %s verbs: one after the operation keyword and one
+// inside the course node. params are the query's own variable declarations.
+func (c *Client) localeQuery(query string, params []string, locale language.Tag, vars map[string]interface{}) string {
+	selection := ""
+	if c.translates(locale) {
+		params = append(params, "$locale: String!")
+		vars["locale"] = locale.String()
+		selection = translationSelection
+	}
+
+	decl := ""
+	if len(params) > 0 {
+		decl = "(" + strings.Join(params, ", ") + ")"
+	}
+	return fmt.Sprintf(query, decl, selection)
+}
+
+// localise overlays the translated copy on course field by field, keeping the
+// default-language value wherever the translation is empty.
+func localise(course *models.Course, locale language.Tag) {
+	for _, edge := range course.Translations.Edges {
+		tr := edge.Node
+		if tr.Locale != locale.String() {
+			continue
+		}
+		overlay(&course.CourseName, tr.CourseName)
+		overlay(&course.DeliveryLongText, tr.DeliveryLongText)
+		overlay(&course.Overview, tr.Overview)
+		overlay(&course.WhoIsItFor, tr.WhoIsItFor)
+		overlay(&course.WhatYoullLearn, tr.WhatYoullLearn)
+		overlay(&course.DurationAndStudyLoad, tr.DurationAndStudyLoad)
+		overlay(&course.JobOutcomes, tr.JobOutcomes)
+		overlay(&course.EntryRequirements, tr.EntryRequirements)
+		overlay(&course.CourseFeatures, tr.CourseFeatures)
+		overlay(&course.WorkPlacement, tr.WorkPlacement)
+		overlay(&course.RecognitionOfPriorLearning, tr.RecognitionOfPriorLearning)
+		overlay(&course.Assessment, tr.Assessment)
+		overlay(&course.FurtherStudyAndEducationPathways, tr.FurtherStudyAndEducationPathways)
+		overlay(&course.ProfessionalRecognition, tr.ProfessionalRecognition)
+		overlay(&course.Materials, tr.Materials)
+		overlay(&course.PaymentOptions, tr.PaymentOptions)
+		overlay(&course.AdditionalInformation, tr.AdditionalInformation)
+		if len(tr.CourseModule) > 0 && string(tr.CourseModule) != "null" {
+			course.CourseModule = tr.CourseModule
+		}
+		if len(tr.TopPanel) > 0 && string(tr.TopPanel) != "null" {
+			course.TopPanel = tr.TopPanel
+		}
+		return
+	}
+}
+
+func overlay(field *string, translated string) {
+	if translated != "" {
+		*field = translated
+	}
+}
@@ -1,19 +1,54 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/auth"
 
 	"github.com/gin-gonic/gin"
 )
 
-// AuthCallback handles the authentication callback.
-// In a real implementation, this would verify the token with Supabase and establish a session.
-func AuthCallback(c *gin.Context) {
+// AuthCallback handles the authentication callback. When AUTH_JWT_SECRET is
+// set, the token is verified and a session started for its subject, and the
+// courses saved or viewed before signing in are merged into the user's lists.
+func (h *Handler) AuthCallback(c *gin.Context) {
 	token := c.PostForm("token")
 	if token == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing token"})
 		return
 	}
-	// TODO: Validate token with Supabase or your auth provider.
-	c.JSON(http.StatusOK, gin.H{"message": "Auth callback successful", "token": token})
+	if h.cfg.Auth.JWTSecret == "" {
+		// TODO: Validate token with Supabase or your auth provider.
+		c.JSON(http.StatusOK, gin.H{"message": "Auth callback successful", "token": token})
+		return
+	}
+
+	claims, err := auth.VerifyToken(token, []byte(h.cfg.Auth.JWTSecret), time.Now())
+	if err != nil {
+		log.Println("Rejected sign-in token:", err)
+		message := "Invalid token"
+		if errors.Is(err, auth.ErrExpiredToken) {
+			message = "Token expired"
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": message})
+		return
+	}
+
+	if err := h.lists.SignIn(c, claims.Subject); err != nil {
+		log.Println("Failed to merge saved courses on sign-in:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Sign-in failed"})
+		return
+	}
+	c.Header("HX-Trigger", listsChanged)
+	c.JSON(http.StatusOK, gin.H{"message": "Auth callback successful", "user": claims.Subject})
+}
+
+// SignOut ends the session. Lists saved while signed in stay with the user.
+func (h *Handler) SignOut(c *gin.Context) {
+	h.sessions.End(c)
+	c.Header("HX-Trigger", listsChanged)
+	c.Status(http.StatusNoContent)
 }
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/catalog"
+	"github.com/Tonnie-Exelero/go-ms-kit/geo"
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+	"github.com/Tonnie-Exelero/go-ms-kit/middleware"
+	"github.com/Tonnie-Exelero/go-ms-kit/search"
+	"github.com/Tonnie-Exelero/go-ms-kit/templates"
+	"github.com/Tonnie-Exelero/go-ms-kit/tenant"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSuggestions = 8
+	maxSuggestions     = 20
+)
+
+type suggestionResult struct {
+	Kind       search.Kind `json:"kind"`
+	Text       string      `json:"text"`
+	CourseID   int         `json:"course_id,omitempty"`
+	URL        string      `json:"url"`
+	Highlights [][2]int    `json:"highlights"`
+}
+
+// AutocompleteHandler suggests course codes, course names and subject names
+// starting with what has been typed into the search box (q). It renders the
+// suggestion list for the header's HTMX input, or JSON when asked for with
+// format=json or an Accept header.
+func (h *Handler) AutocompleteHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+	query := catalog.ParseQuery(c.Request.URL.Query(), h.tenant(c).DefaultTag)
+
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultSuggestions
+	}
+	limit = min(limit, maxSuggestions)
+
+	var suggestions []search.Suggestion
+	if query.Text != "" {
+		keep, err := h.visibleCourse(c, query.Tag)
+		if err == nil {
+			suggestions, err = h.search.Suggest(ctx, h.tenant(c).Graph, query.Tag, i18n.FromContext(ctx), query.Text, limit, keep)
+		}
+		if err != nil {
+			// Autocomplete is a nicety; an empty list lets the learner carry on
+			// typing and submit a full search
+			log.Println("Failed to suggest courses:", err)
+			suggestions = nil
+		}
+	}
+
+	// Suggestions only change when the catalogue is refreshed, unless they
+	// depend on where the learner is. The tenant comes from the host, path
+	// or one of the headers varied on, so shared caches keep tenants apart.
+	if h.geoPolicy(c).Enabled() {
+		c.Header("Cache-Control", "private, max-age=60")
+	} else {
+		c.Header("Cache-Control", "public, max-age=60")
+	}
+	c.Writer.Header().Add("Vary", "Accept")
+	c.Writer.Header().Add("Vary", tenant.PartnerHeader)
+	c.Writer.Header().Add("Vary", tenant.APIKeyHeader)
+	c.Writer.Header().Add("Vary", middleware.LocaleHeader)
+
+	if c.Query("format") == "json" || c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON {
+		results := []suggestionResult{}
+		for _, s := range suggestions {
+			results = append(results, suggestionResult{
+				Kind:       s.Kind,
+				Text:       s.Text,
+				CourseID:   s.CourseID,
+				URL:        templates.SuggestionURL(ctx, s, query.Tag),
+				Highlights: s.Highlights,
+			})
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"query":       query.Text,
+			"suggestions": results,
+		})
+		return
+	}
+
+	c.Writer.Header().Set("Content-Type", "text/html")
+	err = templates.Suggestions(suggestions, query.Tag).Render(ctx, c.Writer)
+	if err != nil {
+		log.Println("Failed to render suggestions:", err)
+		c.String(http.StatusInternalServerError, "Template render error: %v", err)
+	}
+}
+
+// visibleCourse returns whether a course can be suggested where the visitor
+// is, or nil when geo-targeting leaves every course visible.
+func (h *Handler) visibleCourse(c *gin.Context, tag string) (func(courseID int) bool, error) {
+	ctx := c.Request.Context()
+	country := geo.FromContext(ctx)
+	policy := h.geoPolicy(c)
+	if !policy.Enabled() || country == "" {
+		return nil, nil
+	}
+
+	courses, err := h.tenant(c).Graph.GetCourses(ctx, tag, i18n.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	restricted := map[int]bool{}
+	for _, course := range courses {
+		if policy.Access(course, country) == geo.Restricted {
+			restricted[course.ID] = true
+		}
+	}
+	return func(courseID int) bool { return !restricted[courseID] }, nil
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/compare"
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+	"github.com/Tonnie-Exelero/go-ms-kit/templates"
+	"github.com/Tonnie-Exelero/go-ms-kit/tenant"
+
+	"github.com/gin-gonic/gin"
+)
+
+type compareCourse struct {
+	ID       int    `json:"id"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+	URL      string `json:"url"`
+}
+
+type compareRow struct {
+	Field   compare.Field `json:"field"`
+	Label   string        `json:"label"`
+	Values  []string      `json:"values"`
+	Differs bool          `json:"differs"`
+}
+
+// CompareHandler renders the courses in ids side by side, highlighting the
+// fields that differ. Opened from the compare tray it renders into the modal;
+// otherwise it returns the bare table, or JSON when asked for with
+// format=json or an Accept header.
+func (h *Handler) CompareHandler(c *gin.Context) {
+	ids := compare.ParseIDs(c.QueryArray("ids"))
+	if len(ids) == 0 {
+		c.String(http.StatusBadRequest, "ids is required")
+		return
+	}
+
+	ctx := c.Request.Context()
+	courses, err := h.coursesByID(c, ids)
+	if err != nil {
+		log.Println("Failed to fetch courses to compare:", err)
+		c.String(http.StatusBadGateway, "Failed to fetch courses")
+		return
+	}
+	table := compare.Build(ctx, courses, time.Now())
+
+	if c.Query("format") == "json" || c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON {
+		out := []compareCourse{}
+		for _, course := range table.Courses {
+			out = append(out, compareCourse{
+				ID:       course.ID,
+				Code:     course.CourseCode,
+				Name:     course.CourseName,
+				Provider: course.Brand.ProviderName,
+				URL:      tenant.Path(ctx, "/courses/"+course.IDText),
+			})
+		}
+		rows := []compareRow{}
+		for _, row := range table.Rows {
+			rows = append(rows, compareRow{
+				Field:   row.Field,
+				Label:   i18n.T(ctx, "compare."+string(row.Field)),
+				Values:  row.Text(),
+				Differs: row.Differs,
+			})
+		}
+		c.JSON(http.StatusOK, gin.H{"courses": out, "rows": rows})
+		return
+	}
+
+	component := templates.Compare(table)
+	if c.GetHeader("HX-Target") == "mf-modal" {
+		component = templates.CompareModal(table)
+	}
+
+	c.Writer.Header().Set("Content-Type", "text/html")
+	c.Writer.Header().Add("Vary", "Accept")
+	c.Writer.Header().Add("Vary", "HX-Target")
+	err = component.Render(ctx, c.Writer)
+	if err != nil {
+		log.Println("Failed to render comparison:", err)
+		c.String(http.StatusInternalServerError, "Template render error: %v", err)
+		return
+	}
+}
+
+// CompareTrayHandler renders the compare tray for ids, after adding or
+// removing the course in toggle. The tray keeps its IDs in a hidden form that
+// the cards' compare buttons include.
+func (h *Handler) CompareTrayHandler(c *gin.Context) {
+	ids := compare.ParseIDs(c.QueryArray("ids"))
+	full := false
+	if toggle, err := strconv.Atoi(c.Query("toggle")); err == nil && toggle > 0 {
+		var ok bool
+		ids, ok = compare.Toggle(ids, toggle)
+		full = !ok
+	}
+
+	courses, err := h.coursesByID(c, ids)
+	if err != nil {
+		log.Println("Failed to fetch courses to compare:", err)
+		c.String(http.StatusBadGateway, "Failed to fetch courses")
+		return
+	}
+
+	c.Writer.Header().Set("Content-Type", "text/html")
+	err = templates.CompareTray(courses, full).Render(c.Request.Context(), c.Writer)
+	if err != nil {
+		log.Println("Failed to render compare tray:", err)
+		c.String(http.StatusInternalServerError, "Template render error: %v", err)
+		return
+	}
+}
+
+// coursesByID fetches courses in the request locale, in the order given.
+// Courses that no longer exist, or are restricted where the visitor is, are
+// left out.
+func (h *Handler) coursesByID(c *gin.Context, ids []int) ([]graph.CourseView, error) {
+	ctx := c.Request.Context()
+	var courses []graph.CourseView
+	for _, id := range ids {
+		course, err := h.tenant(c).Graph.GetCourseByID(ctx, id, i18n.FromContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if course.ID != 0 {
+			courses = append(courses, course)
+		}
+	}
+	return h.visible(c, courses), nil
+}
@@ -1,39 +1,46 @@
 package handlers
 
 import (
-	"encoding/json"
 	"log"
 	"net/http"
-	"os"
 	"strconv"
 
-	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
 	"github.com/Tonnie-Exelero/go-ms-kit/templates"
 
 	"github.com/gin-gonic/gin"
 	"github.com/microcosm-cc/bluemonday"
 )
 
-func CoursesHandler(c *gin.Context) {
-	// Extract the filter from the query parameter "tag"
-	tagFilter := c.DefaultQuery("tag", "") // Default to "marketing"
+// CoursesHandler renders the results (filter bar and cards) for HTMX to swap
+// in when the list changes: filters, shuffle, reset. Opened directly it
+// renders the full page, so list URLs can be shared.
+func (h *Handler) CoursesHandler(c *gin.Context) {
+	query := h.listQuery(c)
 
-	courses, err := graph.GetCourses(tagFilter)
+	list, err := h.courseList(c, query)
 	if err != nil {
 		log.Println("Failed to fetch courses:", err)
+		c.String(http.StatusBadGateway, "Failed to fetch courses")
 		return
 	}
 
+	component := templates.Home(list)
+	if resultsOnly(c) {
+		component = templates.Results(list)
+	}
+
 	c.Writer.Header().Set("Content-Type", "text/html")
-	err = templates.Home(&courses).Render(c, c.Writer)
+	c.Writer.Header().Add("Vary", "HX-Target")
+	err = component.Render(c.Request.Context(), c.Writer)
 	if err != nil {
-		log.Println("Failed to render index:", err)
+		log.Println("Failed to render courses:", err)
 		c.String(http.StatusInternalServerError, "Template render error: %v", err)
 		return
 	}
 }
 
-func CourseHandler(c *gin.Context) {
+func (h *Handler) CourseHandler(c *gin.Context) {
 	idParam := c.Param("id")
   	courseID, err := strconv.Atoi(idParam)
     if err != nil {
@@ -47,7 +54,7 @@ func CourseHandler(c *gin.Context) {
 		return
 	}
 
-	course, err := graph.GetCourseByID(courseID)
+	course, err := h.tenant(c).Graph.GetCourseByID(c.Request.Context(), courseID, i18n.FromContext(c.Request.Context()))
 	if err != nil {
 	  	log.Printf("Error fetching course %d: %v\n", courseID, err)
 	  	return
@@ -58,33 +65,15 @@ func CourseHandler(c *gin.Context) {
 		return
 	}
 
-	subjectsJson := course.CourseModule
-
-	var parsedItems []templates.SubjectItem
-	if string(subjectsJson) != "" {
-        // First attempt: Direct JSON array
-        if err := json.Unmarshal(subjectsJson, &parsedItems); err != nil {
-            // Second attempt: JSON string containing array
-            var jsonStr string
-            if err := json.Unmarshal(subjectsJson, &jsonStr); err == nil && jsonStr != "" {
-                if err := json.Unmarshal([]byte(jsonStr), &parsedItems); err != nil {
-                    log.Println("Failed to parse course_module JSON:", err)
-                }
-            } else {
-                log.Println("Failed to parse course_module:", err)
-            }
-        }
-    }
-
-	groups := groupByModule(parsedItems)
-
-	iframeURL := os.Getenv("ENQUIRE_FORM_URL")
-	if iframeURL == "" {
-		iframeURL = "http://localhost:8081" // optional fallback
+	// The native form is loaded by the detail view instead of the iframe
+	var iframeURL string
+	if !h.cfg.Enquiry.Native() {
+		iframeURL = h.enquireFormURL(c)
 	}
+	h.recordView(c, course.ID)
 
 	c.Writer.Header().Set("Content-Type", "text/html")
-	err = templates.Modal(&course, &groups, iframeURL).Render(c, c.Writer)
+	err = templates.Modal(&course, iframeURL, h.banners(c, course), h.access(c, course)).Render(c.Request.Context(), c.Writer)
 	if err != nil {
 		log.Println("Failed to render modal:", err)
 		c.String(http.StatusInternalServerError, "Failed to render modal: %v", err)
@@ -92,41 +81,11 @@ func CourseHandler(c *gin.Context) {
 	}
 }
 
-func CloseModal(c *gin.Context) {
+func (h *Handler) CloseModal(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
-func groupByModule(items []templates.SubjectItem) []templates.ModuleGroup {
-	var groups []templates.ModuleGroup
-	var current *templates.ModuleGroup
-
-	for _, item := range items {
-		if item.Module != "" {
-			// start a new group
-			g := templates.ModuleGroup{
-				Module:   item.Module,
-				Subjects: []templates.SubjectItem{{
-					Code:    item.Code,
-					Name:    item.Name,
-					Details: safeHTML(item.Details),
-				}},
-			}
-			groups = append(groups, g)
-			current = &groups[len(groups)-1]
-		} else if current != nil {
-			// append to the most recent group
-			current.Subjects = append(current.Subjects, templates.SubjectItem{
-				Code:    item.Code,
-				Name:    item.Name,
-				Details: safeHTML(item.Details),
-			})
-		}
-	}
-
-	return groups
-}
-
-func InfoHandler(c *gin.Context) {
+func (h *Handler) InfoHandler(c *gin.Context) {
   idParam := c.Param("id")
   courseID, err := strconv.Atoi(idParam)
   if err != nil {
@@ -136,7 +95,7 @@ func InfoHandler(c *gin.Context) {
 
   section := c.Query("section")
 
-  course, err := graph.GetCourseByID(courseID)
+  course, err := h.tenant(c).Graph.GetCourseByID(c.Request.Context(), courseID, i18n.FromContext(c.Request.Context()))
   if err != nil {
 	log.Printf("Error fetching course %d: %v\n", courseID, err)
 	return
@@ -165,7 +124,7 @@ func InfoHandler(c *gin.Context) {
   c.String(http.StatusOK, partialHTML)
 }
 
-func CareerHandler(c *gin.Context) {
+func (h *Handler) CareerHandler(c *gin.Context) {
   idParam := c.Param("id")
   courseID, err := strconv.Atoi(idParam)
   if err != nil {
@@ -175,7 +134,7 @@ func CareerHandler(c *gin.Context) {
 
   section := c.Query("section")
 
-  course, err := graph.GetCourseByID(courseID)
+  course, err := h.tenant(c).Graph.GetCourseByID(c.Request.Context(), courseID, i18n.FromContext(c.Request.Context()))
   if err != nil {
     log.Printf("Error fetching course %d: %v\n", courseID, err)
     return
@@ -198,7 +157,7 @@ func CareerHandler(c *gin.Context) {
   c.String(http.StatusOK, partialHTML)
 }
 
-func RecognitionHandler(c *gin.Context) {
+func (h *Handler) RecognitionHandler(c *gin.Context) {
   idParam := c.Param("id")
   courseID, err := strconv.Atoi(idParam)
   if err != nil {
@@ -208,7 +167,7 @@ func RecognitionHandler(c *gin.Context) {
 	
   section := c.Query("section")
 
-  course, err := graph.GetCourseByID(courseID)
+  course, err := h.tenant(c).Graph.GetCourseByID(c.Request.Context(), courseID, i18n.FromContext(c.Request.Context()))
   if err != nil {
 	log.Printf("Error fetching course %d: %v\n", courseID, err)
 	return
@@ -231,7 +190,7 @@ func RecognitionHandler(c *gin.Context) {
   c.String(http.StatusOK, partialHTML)
 }
 
-func EligibilityHandler(c *gin.Context) {
+func (h *Handler) EligibilityHandler(c *gin.Context) {
   idParam := c.Param("id")
   courseID, err := strconv.Atoi(idParam)
   if err != nil {
@@ -241,7 +200,7 @@ func EligibilityHandler(c *gin.Context) {
 
   section := c.Query("section")
 
-  course, err := graph.GetCourseByID(courseID)
+  course, err := h.tenant(c).Graph.GetCourseByID(c.Request.Context(), courseID, i18n.FromContext(c.Request.Context()))
   if err != nil {
 	log.Printf("Error fetching course %d: %v\n", courseID, err)
 	return
@@ -264,7 +223,7 @@ func EligibilityHandler(c *gin.Context) {
   c.String(http.StatusOK, partialHTML)
 }
 
-func CurriculumHandler(c *gin.Context) {
+func (h *Handler) CurriculumHandler(c *gin.Context) {
   idParam := c.Param("id")
   courseID, err := strconv.Atoi(idParam)
   if err != nil {
@@ -274,7 +233,7 @@ func CurriculumHandler(c *gin.Context) {
 
   section := c.Query("section")
 
-  course, err := graph.GetCourseByID(courseID)
+  course, err := h.tenant(c).Graph.GetCourseByID(c.Request.Context(), courseID, i18n.FromContext(c.Request.Context()))
   if err != nil {
 	log.Printf("Error fetching course %d: %v\n", courseID, err)
 	return
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// csrfExempt lists the route prefixes that authenticate with a bearer token
+// rather than cookies. A cross-site page can't send that header, so they
+// need no CSRF token.
+var csrfExempt = []string{"/api/"}
+
+// CSRF refuses state-changing requests that don't send back the learner's
+// CSRF token, and puts the token in the request context for the templates to
+// render into forms and HTMX request headers.
+func (h *Handler) CSRF(c *gin.Context) {
+	for _, prefix := range csrfExempt {
+		if strings.HasPrefix(c.FullPath(), prefix) {
+			c.Next()
+			return
+		}
+	}
+
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		if !h.csrf.Check(c) {
+			log.Printf("Rejected %s %s: missing or invalid CSRF token\n", c.Request.Method, c.Request.URL.Path)
+			c.String(http.StatusForbidden, "Invalid form token, please reload the page")
+			c.Abort()
+			return
+		}
+	}
+
+	c.Request = c.Request.WithContext(auth.WithCSRFToken(c.Request.Context(), h.csrf.Token(c)))
+	c.Next()
+}
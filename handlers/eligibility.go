@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/config"
+	"github.com/Tonnie-Exelero/go-ms-kit/eligibility"
+	"github.com/Tonnie-Exelero/go-ms-kit/geo"
+	"github.com/Tonnie-Exelero/go-ms-kit/templates"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EligibilityCheckHandler runs the eligibility self-check one question at a
+// time. The answers so far come as URL params; it renders the first
+// question still unanswered, or the result once they all are.
+func (h *Handler) EligibilityCheckHandler(c *gin.Context) {
+	course, ok := h.routeCourse(c)
+	if !ok {
+		return
+	}
+	rules := course.Eligibility
+	if !eligibility.Enabled(rules) {
+		c.String(http.StatusNotFound, "No eligibility rules for this course")
+		return
+	}
+
+	answers := eligibility.ParseAnswers(c.Request.URL.Query())
+	c.Header("Cache-Control", "no-store")
+	c.Writer.Header().Set("Content-Type", "text/html")
+
+	var err error
+	if q, ok := eligibility.Next(rules, answers); ok {
+		err = templates.EligibilityStep(&course, q, answers).Render(c.Request.Context(), c.Writer)
+	} else {
+		// The result can fill in the native enquiry form, when there is one
+		prefill := h.cfg.Enquiry.Native() && allows(c, config.FeatureEnquire) && h.access(c, course) != geo.Restricted
+		result := eligibility.Check(rules, answers)
+		err = templates.EligibilityResult(&course, answers, result, prefill).Render(c.Request.Context(), c.Writer)
+	}
+	if err != nil {
+		log.Println("Failed to render eligibility check:", err)
+		c.String(http.StatusInternalServerError, "Template render error: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/config"
+	"github.com/Tonnie-Exelero/go-ms-kit/eligibility"
+	"github.com/Tonnie-Exelero/go-ms-kit/geo"
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+	"github.com/Tonnie-Exelero/go-ms-kit/leads"
+	"github.com/Tonnie-Exelero/go-ms-kit/templates"
+
+	"github.com/gin-gonic/gin"
+)
+
+// honeypotField is a form field hidden from people; bots that fill it in are
+// thanked and ignored.
+const honeypotField = "website"
+
+// EnquiryFormHandler renders the native enquiry form for a course. The detail
+// view loads it in place of the enquiry iframe when ENQUIRY_FORM is native.
+// Eligibility self-check answers in the URL prefill the message.
+func (h *Handler) EnquiryFormHandler(c *gin.Context) {
+	if !allows(c, config.FeatureEnquire) {
+		c.String(http.StatusNotFound, "Enquiries not enabled")
+		return
+	}
+	course, ok := h.routeCourse(c)
+	if !ok {
+		return
+	}
+	if h.access(c, course) == geo.Restricted {
+		h.renderUnavailable(c, http.StatusOK)
+		return
+	}
+
+	var form leads.Form
+	answers := eligibility.ParseAnswers(c.Request.URL.Query())
+	if len(answers.Values(course.Eligibility)) > 0 {
+		form.Message = templates.EligibilitySummary(c.Request.Context(), &course, answers)
+	}
+	h.renderEnquiry(c, http.StatusOK, &course, form, nil)
+}
+
+// EnquiryHandler validates and stores an enquiry, then hands it to the lead
+// webhook. The CSRF middleware has already checked the form's token.
+// Invalid submissions get the form back with the errors marked.
+func (h *Handler) EnquiryHandler(c *gin.Context) {
+	if !allows(c, config.FeatureEnquire) {
+		c.String(http.StatusNotFound, "Enquiries not enabled")
+		return
+	}
+	course, ok := h.routeCourse(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+
+	if h.access(c, course) == geo.Restricted {
+		log.Printf("Refused enquiry for course %d: restricted in %s\n", course.ID, geo.FromContext(ctx))
+		h.renderUnavailable(c, swapStatus(c, http.StatusForbidden))
+		return
+	}
+
+	if c.PostForm(honeypotField) != "" {
+		log.Printf("Dropped enquiry for course %d: honeypot filled\n", course.ID)
+		h.renderThanks(c)
+		return
+	}
+
+	form := leads.Form{
+		Name:    c.PostForm("name"),
+		Email:   c.PostForm("email"),
+		Phone:   c.PostForm("phone"),
+		Message: c.PostForm("message"),
+		Consent: c.PostForm("consent") != "",
+	}
+	lead, errs := leads.Validate(form)
+	if len(errs) > 0 {
+		h.renderEnquiry(c, swapStatus(c, http.StatusUnprocessableEntity), &course, form, errs)
+		return
+	}
+
+	lead.ID = leads.NewID()
+	lead.CourseID = course.ID
+	lead.CourseCode = course.CourseCode
+	lead.CourseName = course.CourseName
+	lead.Locale = i18n.FromContext(ctx).String()
+	lead.Tenant = h.tenant(c).ID
+	if p, ok := h.partner(c); ok {
+		lead.Partner = p.Key
+	}
+	lead.CreatedAt = time.Now().UTC()
+
+	if err := h.leads.Save(ctx, lead); err != nil {
+		log.Printf("Failed to store enquiry for course %d: %v\n", course.ID, err)
+		c.String(http.StatusInternalServerError, "Failed to send enquiry")
+		return
+	}
+	if h.forwarder != nil {
+		h.forwarder.Enqueue(lead)
+	}
+	h.renderThanks(c)
+}
+
+// swapStatus returns status, or 200 for HTMX requests so the reply replaces
+// the form: HTMX 1.9 doesn't swap error responses.
+func swapStatus(c *gin.Context, status int) int {
+	if c.GetHeader("HX-Request") == "true" {
+		return http.StatusOK
+	}
+	return status
+}
+
+func (h *Handler) renderEnquiry(c *gin.Context, status int, course *graph.CourseView, form leads.Form, errs leads.Errors) {
+	c.Header("Cache-Control", "no-store")
+	c.Writer.Header().Set("Content-Type", "text/html")
+	c.Status(status)
+	err := templates.EnquiryForm(course, form, errs).Render(c.Request.Context(), c.Writer)
+	if err != nil {
+		log.Println("Failed to render enquiry form:", err)
+		c.String(http.StatusInternalServerError, "Template render error: %v", err)
+	}
+}
+
+func (h *Handler) renderThanks(c *gin.Context) {
+	c.Writer.Header().Set("Content-Type", "text/html")
+	err := templates.EnquiryThanks().Render(c.Request.Context(), c.Writer)
+	if err != nil {
+		log.Println("Failed to render enquiry confirmation:", err)
+		c.String(http.StatusInternalServerError, "Template render error: %v", err)
+	}
+}
+
+func (h *Handler) renderUnavailable(c *gin.Context, status int) {
+	c.Writer.Header().Set("Content-Type", "text/html")
+	c.Status(status)
+	err := templates.EnquiryUnavailable().Render(c.Request.Context(), c.Writer)
+	if err != nil {
+		log.Println("Failed to render enquiry notice:", err)
+		c.String(http.StatusInternalServerError, "Template render error: %v", err)
+	}
+}
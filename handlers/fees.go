@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/fees"
+	"github.com/Tonnie-Exelero/go-ms-kit/templates"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dateLayout is the format of the fee calculator's start param.
+const dateLayout = "2006-01-02"
+
+type feeOption struct {
+	Key         string  `json:"key"`
+	Label       string  `json:"label"`
+	Frequency   string  `json:"frequency,omitempty"`
+	Instalments int     `json:"instalments,omitempty"`
+	AdminFee    float64 `json:"admin_fee"`
+}
+
+type feePayment struct {
+	Kind   string  `json:"kind"`
+	Due    string  `json:"due"`
+	Amount float64 `json:"amount"`
+}
+
+// FeesHandler quotes the course's fees under a payment plan (plan, upfront
+// by default), less the discount of any promotion running on the course.
+// Payments start on start (YYYY-MM-DD, today by default). It renders the
+// fee calculator, which calls it again as the learner picks a plan, or JSON
+// when asked for with format=json or an Accept header.
+func (h *Handler) FeesHandler(c *gin.Context) {
+	course, ok := h.routeCourse(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+
+	start := time.Now()
+	if s := c.Query("start"); s != "" {
+		var err error
+		if start, err = time.Parse(dateLayout, s); err != nil {
+			c.String(http.StatusBadRequest, "start must be a YYYY-MM-DD date")
+			return
+		}
+	}
+
+	var discount float64
+	if d := h.banners(c, course).Discount; d != nil {
+		discount = d.Discount
+	}
+
+	quote, err := fees.Calculate(course.Fees, c.Query("plan"), discount, start)
+	switch {
+	case errors.Is(err, fees.ErrNoFees):
+		c.String(http.StatusNotFound, "No fees for this course")
+		return
+	case err != nil:
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+
+	if c.Query("format") == "json" || c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON {
+		options := []feeOption{}
+		for _, o := range quote.Options {
+			options = append(options, feeOption{
+				Key:         o.Key,
+				Label:       templates.FeeOptionLabel(ctx, o),
+				Frequency:   o.Frequency,
+				Instalments: o.Instalments,
+				AdminFee:    o.AdminFee.Major(),
+			})
+		}
+		schedule := []feePayment{}
+		for _, p := range quote.Payments {
+			schedule = append(schedule, feePayment{
+				Kind:   p.Kind,
+				Due:    p.Due.Format(dateLayout),
+				Amount: p.Amount.Major(),
+			})
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"course_id":        course.ID,
+			"currency":         quote.Currency,
+			"plan":             quote.Option.Key,
+			"plans":            options,
+			"price":            quote.Price.Major(),
+			"discount_percent": quote.DiscountPercent,
+			"discount":         quote.Discount.Major(),
+			"admin_fee":        quote.Option.AdminFee.Major(),
+			"total":            quote.Total.Major(),
+			"schedule":         schedule,
+		})
+		return
+	}
+
+	c.Writer.Header().Set("Content-Type", "text/html")
+	c.Writer.Header().Add("Vary", "Accept")
+	err = templates.FeeCalculator(&course, quote).Render(ctx, c.Writer)
+	if err != nil {
+		log.Println("Failed to render fee calculator:", err)
+		c.String(http.StatusInternalServerError, "Template render error: %v", err)
+	}
+}
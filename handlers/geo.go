@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"github.com/Tonnie-Exelero/go-ms-kit/geo"
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Locate puts the visitor's country in the request context.
+func (h *Handler) Locate(c *gin.Context) {
+	if country := h.locator.Country(c); country != "" {
+		c.Request = c.Request.WithContext(geo.WithCountry(c.Request.Context(), country))
+	}
+	c.Next()
+}
+
+// geoPolicy returns the tenant's geo-targeting rules.
+func (h *Handler) geoPolicy(c *gin.Context) geo.Policy {
+	return geo.Policy{Countries: h.tenant(c).GeoCountries}
+}
+
+// access returns what the visitor may do with course where they are.
+func (h *Handler) access(c *gin.Context, course graph.CourseView) geo.Access {
+	return h.geoPolicy(c).Access(course, geo.FromContext(c.Request.Context()))
+}
+
+// visible drops the courses restricted where the visitor is.
+func (h *Handler) visible(c *gin.Context, courses []graph.CourseView) []graph.CourseView {
+	return h.geoPolicy(c).Filter(courses, geo.FromContext(c.Request.Context()))
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/auth"
+	"github.com/Tonnie-Exelero/go-ms-kit/config"
+	"github.com/Tonnie-Exelero/go-ms-kit/geo"
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+	"github.com/Tonnie-Exelero/go-ms-kit/leads"
+	"github.com/Tonnie-Exelero/go-ms-kit/promo"
+	"github.com/Tonnie-Exelero/go-ms-kit/recommend"
+	"github.com/Tonnie-Exelero/go-ms-kit/saved"
+	"github.com/Tonnie-Exelero/go-ms-kit/search"
+	"github.com/Tonnie-Exelero/go-ms-kit/tenant"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler carries the dependencies shared by the HTTP handlers.
+type Handler struct {
+	cfg         *config.Config
+	tenants     *tenant.Registry
+	promos      promo.Source
+	search      *search.Service
+	recommender recommend.Recommender
+	sessions    *auth.Sessions
+	lists       *saved.Service
+	csrf        *auth.CSRF
+	leads       leads.LeadStore
+	// forwarder is nil when no lead webhook is configured
+	forwarder *leads.Forwarder
+	locator   geo.Locator
+}
+
+// New returns a Handler using the given configuration and tenants. It must be
+// called before the tenants' cache refreshers start, so search indexes follow
+// their refreshes.
+func New(cfg *config.Config, tenants *tenant.Registry) *Handler {
+	cookies := auth.Cookies{
+		Signer: auth.NewSigner([]byte(cfg.Auth.SessionSecret)),
+		Secure: !cfg.Development(),
+	}
+	sessions := auth.NewSessions(cookies, cfg.Auth.SessionTTL)
+
+	h := &Handler{
+		cfg:         cfg,
+		tenants:     tenants,
+		promos:      promo.NewFileSource(cfg.Promotions.File),
+		search:      search.NewService(),
+		recommender: recommend.NewSimilar(recommend.DefaultWeights),
+		sessions:    sessions,
+		lists:       saved.NewService(saved.NewFileStore(cfg.Lists.File), sessions, cookies),
+		csrf:        auth.NewCSRF(cookies),
+		leads:       leads.NewFileStore(cfg.Enquiry.LeadsFile),
+	}
+	h.locator = newLocator(cfg.Geo)
+	if cfg.Enquiry.WebhookURL != "" {
+		h.forwarder = leads.NewForwarder(cfg.Enquiry.WebhookURL, []byte(cfg.Enquiry.WebhookSecret), h.leads)
+	}
+	for _, client := range tenants.Clients() {
+		h.search.Watch(client)
+	}
+	return h
+}
+
+// newLocator finds countries from the proxy header, then the GeoIP
+// database, whichever are configured.
+func newLocator(cfg config.Geo) geo.Locator {
+	var chain geo.Chain
+	if cfg.CountryHeader != "" {
+		chain = append(chain, geo.HeaderLocator{Header: cfg.CountryHeader})
+	}
+	if cfg.Database != "" {
+		db, err := geo.OpenDatabase(cfg.Database)
+		if err != nil {
+			log.Println("Failed to load GeoIP database, countries only come from the proxy header:", err)
+		} else {
+			chain = append(chain, geo.DatabaseLocator{DB: db})
+		}
+	}
+	return chain
+}
+
+// ForwardLeads delivers enquiries to the lead webhook until ctx is done. It
+// returns at once when no webhook is configured.
+func (h *Handler) ForwardLeads(ctx context.Context) {
+	if h.forwarder != nil {
+		h.forwarder.Run(ctx)
+	}
+}
+
+// tenant returns the tenant resolved for the request, falling back to the
+// default tenant when the request didn't pass through the tenant middleware.
+func (h *Handler) tenant(c *gin.Context) *tenant.Tenant {
+	if t := tenant.FromContext(c.Request.Context()); t != nil {
+		return t
+	}
+	return h.tenants.Default()
+}
+
+// partner returns the SDK partner the request came from, if any. The key is
+// taken from the route, the SDK's request header or a partner query param.
+func (h *Handler) partner(c *gin.Context) (config.Partner, bool) {
+	key := c.Param("partnerKey")
+	if key == "" {
+		key = c.GetHeader(tenant.PartnerHeader)
+	}
+	if key == "" {
+		key = c.Query("partner")
+	}
+	return h.cfg.Partner(key)
+}
+
+// enquireFormURL is the partner's enquiry form, falling back to the tenant's.
+func (h *Handler) enquireFormURL(c *gin.Context) string {
+	if p, ok := h.partner(c); ok && p.EnquireFormURL != "" {
+		return p.EnquireFormURL
+	}
+	return h.tenant(c).EnquireFormURL
+}
+
+// routeCourse fetches the course named in the route, writing an error
+// response when there isn't one.
+func (h *Handler) routeCourse(c *gin.Context) (graph.CourseView, bool) {
+	courseID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || courseID == 0 {
+		c.String(http.StatusBadRequest, "Invalid course ID")
+		return graph.CourseView{}, false
+	}
+
+	ctx := c.Request.Context()
+	course, err := h.tenant(c).Graph.GetCourseByID(ctx, courseID, i18n.FromContext(ctx))
+	if err != nil {
+		log.Printf("Error fetching course %d: %v\n", courseID, err)
+		c.String(http.StatusBadGateway, "Failed to fetch course")
+		return graph.CourseView{}, false
+	}
+	if course.ID == 0 {
+		c.String(http.StatusNotFound, "Course not found")
+		return graph.CourseView{}, false
+	}
+	return course, true
+}
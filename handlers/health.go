@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/catalog"
+	"github.com/Tonnie-Exelero/go-ms-kit/health"
+	"github.com/Tonnie-Exelero/go-ms-kit/templates"
+
+	"github.com/gin-gonic/gin"
+)
+
+const readinessTimeout = 5 * time.Second
+
+// HealthzHandler reports that the process is alive. It deliberately checks
+// nothing else so orchestrators don't restart us over a backend outage.
+func (h *Handler) HealthzHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+}
+
+// ReadyzHandler runs every registered readiness check and reports each one.
+func (h *Handler) ReadyzHandler(c *gin.Context) {
+	if health.Draining() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "draining"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	results, ready := health.Default.Run(ctx)
+
+	status := http.StatusOK
+	overall := health.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+		overall = health.StatusFail
+	}
+	c.JSON(status, gin.H{"status": overall, "checks": results})
+}
+
+// RegisterReadinessChecks registers the built-in checks: configuration,
+// template rendering, backend reachability and cache warm-up.
+func (h *Handler) RegisterReadinessChecks() {
+	health.Register("config", h.checkConfig)
+	health.Register("templates", checkTemplates)
+	health.Register("backend", h.checkBackend)
+	health.Register("cache", h.checkCache)
+}
+
+func (h *Handler) checkConfig(ctx context.Context) error {
+	return h.cfg.Validate()
+}
+
+func checkTemplates(ctx context.Context) error {
+	return templates.Home(catalog.List{}).Render(ctx, io.Discard)
+}
+
+// A backend counts as available if it answers, or if we still hold an
+// unexpired snapshot of its catalogue to serve from while it recovers.
+func (h *Handler) checkBackend(ctx context.Context) error {
+	var errs []error
+	for _, client := range h.tenants.Clients() {
+		if err := client.Ping(ctx); err != nil && !client.CacheWarmed() {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h *Handler) checkCache(ctx context.Context) error {
+	for _, client := range h.tenants.Clients() {
+		if !client.CacheWarmed() {
+			return errors.New("course cache not warmed")
+		}
+	}
+	return nil
+}
@@ -3,30 +3,67 @@ package handlers
 import (
 	"log"
 	"net/http"
+	"time"
 
+	"github.com/Tonnie-Exelero/go-ms-kit/catalog"
 	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
 	"github.com/Tonnie-Exelero/go-ms-kit/templates"
 
 	"github.com/gin-gonic/gin"
 )
 
 // HomeHandler renders the main index page using Templ
-func HomeHandler(c *gin.Context) {
-	// Extract the filter from the query parameter "tag"
-	tagFilter := c.DefaultQuery("tag", "marketing") // Default to "marketing"
+func (h *Handler) HomeHandler(c *gin.Context) {
+	// Extract the tag, ordering and filters from the query string
+	query := h.listQuery(c)
 
-	courses, err := graph.GetCourses(tagFilter)
+	list, err := h.courseList(c, query)
 	if err != nil {
 		log.Println("Failed to fetch courses:", err)
+		c.String(http.StatusBadGateway, "Failed to fetch courses")
 		return
 	}
 
-
 	c.Writer.Header().Set("Content-Type", "text/html")
-	err = templates.Home(&courses).Render(c, c.Writer)
+	err = templates.Home(list).Render(c.Request.Context(), c.Writer)
 	if err != nil {
 		log.Println("Failed to render index:", err)
 		c.String(http.StatusInternalServerError, "Template render error: %v", err)
 		return
 	}
 }
+
+// courseList fetches the tenant's courses for the query's tag and filters in
+// the request locale, ranked by the search text if there is one, then orders
+// them and counts their facets.
+func (h *Handler) courseList(c *gin.Context, query catalog.Query) (catalog.List, error) {
+	ctx := c.Request.Context()
+	locale := i18n.FromContext(ctx)
+
+	var courses []graph.CourseView
+	if query.Text != "" {
+		hits, err := h.search.Search(ctx, h.tenant(c).Graph, query.Tag, locale, query.Text)
+		if err != nil {
+			return catalog.List{}, err
+		}
+		for _, hit := range hits {
+			courses = append(courses, hit.Course)
+		}
+	} else {
+		// Unfiltered, so the facets can be counted outside the selection
+		var err error
+		courses, err = h.tenant(c).Graph.GetCourses(ctx, query.Tag, locale)
+		if err != nil {
+			return catalog.List{}, err
+		}
+	}
+	courses = h.visible(c, courses)
+	return catalog.NewList(courses, query, locale, time.Now()), nil
+}
+
+// resultsOnly reports whether the request is an HTMX swap of the results,
+// which only needs the filter bar and cards rather than the whole page.
+func resultsOnly(c *gin.Context) bool {
+	return c.GetHeader("HX-Target") == "mf-results"
+}
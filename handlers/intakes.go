@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/calendar"
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IntakesICSHandler serves a course's upcoming intakes as an iCalendar file.
+// A start query param (YYYY-MM-DD) narrows it to a single intake.
+func (h *Handler) IntakesICSHandler(c *gin.Context) {
+	course, ok := h.routeCourse(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+
+	now := time.Now()
+	intakes := course.UpcomingIntakes(now)
+	if start := c.Query("start"); start != "" {
+		intakes = intakesOn(intakes, start)
+	}
+	if len(intakes) == 0 {
+		c.String(http.StatusNotFound, "No upcoming intakes")
+		return
+	}
+
+	events := make([]calendar.Event, 0, len(intakes))
+	for _, intake := range intakes {
+		events = append(events, calendar.Event{
+			UID:         fmt.Sprintf("course-%d-%s@%s", course.ID, intake.DateKey(), c.Request.Host),
+			Summary:     i18n.T(ctx, "calendar.summary", course.CourseName),
+			Description: intakeDescription(c, course, intake),
+			Start:       intake.Start,
+		})
+	}
+
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="course-%d-intakes.ics"`, course.ID))
+	if err := calendar.Write(c.Writer, events, now); err != nil {
+		log.Println("Failed to write calendar:", err)
+	}
+}
+
+// intakesOn keeps the intake starting on the given YYYY-MM-DD date.
+func intakesOn(intakes []models.Intake, date string) []models.Intake {
+	for _, intake := range intakes {
+		if intake.DateKey() == date {
+			return []models.Intake{intake}
+		}
+	}
+	return nil
+}
+
+// intakeDescription summarises the provider, course length and estimated
+// finish date for a calendar entry.
+func intakeDescription(c *gin.Context, course graph.CourseView, intake models.Intake) string {
+	ctx := c.Request.Context()
+	desc := course.Brand.ProviderName
+	if course.Duration.Raw != "" {
+		desc += "\n" + i18n.T(ctx, "calendar.description", i18n.Duration(ctx, course.Duration))
+	}
+	if !intake.End.IsZero() {
+		desc += "\n" + i18n.T(ctx, "detail.intake_ends", i18n.Date(ctx, intake.End))
+	}
+	return desc
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"github.com/Tonnie-Exelero/go-ms-kit/catalog"
+	"github.com/Tonnie-Exelero/go-ms-kit/config"
+	"github.com/Tonnie-Exelero/go-ms-kit/tenant"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdentifyPartner puts the partner whose embed sent the request in the
+// request context, so handlers and templates honour its features.
+func (h *Handler) IdentifyPartner(c *gin.Context) {
+	if partner, ok := h.partner(c); ok {
+		c.Request = c.Request.WithContext(tenant.WithPartner(c.Request.Context(), partner))
+	}
+	c.Next()
+}
+
+// allows reports whether the request's partner has the feature switched on.
+func allows(c *gin.Context, feature string) bool {
+	return tenant.Allows(c.Request.Context(), feature)
+}
+
+// listQuery reads the list options from the URL. A random order falls back
+// to relevance unless the partner has shuffle switched on.
+func (h *Handler) listQuery(c *gin.Context) catalog.Query {
+	query := catalog.ParseQuery(c.Request.URL.Query(), h.tenant(c).DefaultTag)
+	if query.Sort == catalog.Random && !allows(c, config.FeatureShuffle) {
+		query.Sort, query.Seed = catalog.Relevance, 0
+	}
+	return query
+}
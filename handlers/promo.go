@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"log"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/promo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// banners picks the promotions to show on a course's detail view from the
+// configured source.
+func (h *Handler) banners(c *gin.Context, course graph.CourseView) promo.Banners {
+	t := h.tenant(c)
+
+	src := h.promos
+	if h.cfg.Promotions.Source == "graph" {
+		src = promo.FromGraph(t.Graph)
+	}
+
+	promos, err := src.Promotions(c.Request.Context())
+	if err != nil {
+		log.Println("Failed to load promotions:", err)
+	}
+	return promo.Select(promos, course, t.ID, time.Now())
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+	"github.com/Tonnie-Exelero/go-ms-kit/saved"
+	"github.com/Tonnie-Exelero/go-ms-kit/templates"
+
+	"github.com/gin-gonic/gin"
+)
+
+// listsChanged is the HTMX event that refreshes the saved and recently
+// viewed panel.
+const listsChanged = "mf-lists-changed"
+
+// LoadLists puts the learner's saved and recently viewed courses in the
+// request context, so cards can show whether they are saved.
+func (h *Handler) LoadLists(c *gin.Context) {
+	lists, err := h.lists.Load(c, h.tenant(c).ID)
+	if err != nil {
+		log.Println("Failed to load saved courses:", err)
+	}
+	c.Request = c.Request.WithContext(saved.WithLists(c.Request.Context(), lists))
+	c.Next()
+}
+
+// SaveHandler saves the course to the learner's shortlist, or removes it if
+// it is already there, and renders the updated save button. Only courses in
+// the tenant's catalogue can be saved.
+func (h *Handler) SaveHandler(c *gin.Context) {
+	courseID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || courseID <= 0 {
+		c.String(http.StatusBadRequest, "Invalid course ID")
+		return
+	}
+
+	tenantID := h.tenant(c).ID
+	lists, err := h.lists.Load(c, tenantID)
+	if err != nil {
+		log.Println("Failed to load saved courses:", err)
+		c.String(http.StatusInternalServerError, "Failed to save course")
+		return
+	}
+	// Removing needs no lookup, so courses withdrawn since can be unsaved
+	if !lists.IsSaved(courseID) {
+		if _, ok := h.routeCourse(c); !ok {
+			return
+		}
+	}
+	lists, isSaved := lists.Toggle(courseID)
+	if err := h.lists.Save(c, tenantID, lists); err != nil {
+		log.Println("Failed to save course:", err)
+		c.String(http.StatusInternalServerError, "Failed to save course")
+		return
+	}
+
+	c.Header("HX-Trigger", listsChanged)
+	c.Writer.Header().Set("Content-Type", "text/html")
+	err = templates.SaveButton(courseID, isSaved).Render(c.Request.Context(), c.Writer)
+	if err != nil {
+		log.Println("Failed to render save button:", err)
+		c.String(http.StatusInternalServerError, "Template render error: %v", err)
+	}
+}
+
+// SavedHandler renders the learner's saved and recently viewed courses.
+// Courses no longer in the catalogue are dropped from the lists, so they
+// aren't looked up again on every request.
+func (h *Handler) SavedHandler(c *gin.Context) {
+	lists := saved.FromContext(c.Request.Context())
+
+	savedCourses, withdrawn, err := h.listedCourses(c, lists.Saved)
+	if err != nil {
+		log.Println("Failed to fetch saved courses:", err)
+		c.String(http.StatusBadGateway, "Failed to fetch courses")
+		return
+	}
+	recent, withdrawnRecent, err := h.listedCourses(c, lists.Recent)
+	if err != nil {
+		log.Println("Failed to fetch recently viewed courses:", err)
+		c.String(http.StatusBadGateway, "Failed to fetch courses")
+		return
+	}
+	if withdrawn = append(withdrawn, withdrawnRecent...); len(withdrawn) > 0 {
+		if err := h.lists.Save(c, h.tenant(c).ID, lists.Forget(withdrawn)); err != nil {
+			log.Println("Failed to drop withdrawn courses from saved lists:", err)
+		}
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Writer.Header().Set("Content-Type", "text/html")
+	err = templates.SavedLists(savedCourses, recent).Render(c.Request.Context(), c.Writer)
+	if err != nil {
+		log.Println("Failed to render saved courses:", err)
+		c.String(http.StatusInternalServerError, "Template render error: %v", err)
+	}
+}
+
+// recordView adds the course to the recently viewed list. It only logs
+// failures, since they shouldn't stop the course from showing.
+func (h *Handler) recordView(c *gin.Context, courseID int) {
+	tenantID := h.tenant(c).ID
+	lists, err := h.lists.Load(c, tenantID)
+	if err == nil {
+		err = h.lists.Save(c, tenantID, lists.Viewed(courseID))
+	}
+	if err != nil {
+		log.Printf("Failed to record view of course %d: %v\n", courseID, err)
+		return
+	}
+	c.Header("HX-Trigger", listsChanged)
+}
+
+// listedCourses returns the courses with the given IDs in that order, and
+// the IDs no longer in the catalogue. They are looked up in the tenant's
+// cached list for its default tag, and only the ones not listed there are
+// fetched one by one.
+func (h *Handler) listedCourses(c *gin.Context, ids []int) ([]graph.CourseView, []int, error) {
+	if len(ids) == 0 {
+		return nil, nil, nil
+	}
+	ctx := c.Request.Context()
+	locale := i18n.FromContext(ctx)
+
+	byID := map[int]graph.CourseView{}
+	listed, err := h.tenant(c).Graph.GetCourses(ctx, h.tenant(c).DefaultTag, locale)
+	if err != nil {
+		log.Println("Failed to fetch courses, fetching saved courses individually:", err)
+	}
+	for _, course := range listed {
+		byID[course.ID] = course
+	}
+
+	var courses []graph.CourseView
+	var withdrawn []int
+	for _, id := range ids {
+		course, ok := byID[id]
+		if !ok {
+			course, err = h.tenant(c).Graph.GetCourseByID(ctx, id, locale)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		if course.ID == 0 {
+			withdrawn = append(withdrawn, id)
+			continue
+		}
+		courses = append(courses, course)
+	}
+	return h.visible(c, courses), withdrawn, nil
+}
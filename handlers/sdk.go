@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/sdk"
+	"github.com/Tonnie-Exelero/go-ms-kit/theme"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SDKHandler serves each SDK release at its own immutable URL
+// (/sdk/v2.1.0.js), the release lines and latest as aliases for their newest
+// release (/sdk/v2.js, /sdk/latest.js), and the version manifest at
+// /sdk/manifest.json.
+func (h *Handler) SDKHandler(c *gin.Context) {
+	file := c.Param("file")
+
+	// Loaded cross-origin with an integrity attribute, which requires CORS
+	c.Header("Access-Control-Allow-Origin", "*")
+
+	if file == "manifest.json" {
+		manifest, err := sdk.BuildManifest()
+		if err != nil {
+			log.Println("Failed to build SDK manifest:", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "SDK manifest unavailable"})
+			return
+		}
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, manifest)
+		return
+	}
+
+	name, ok := strings.CutSuffix(file, ".js")
+	if !ok {
+		c.String(http.StatusNotFound, "SDK not found")
+		return
+	}
+
+	// A release never changes, so it can be cached forever; an alias moves
+	// to each new release, so keep it short-lived.
+	release, ok := sdk.LookupRelease(name)
+	if ok {
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		version, ok := sdk.Lookup(name)
+		if !ok {
+			c.String(http.StatusNotFound, "SDK version not found")
+			return
+		}
+		release = version.Current()
+		c.Header("Cache-Control", "public, max-age=300")
+		c.Header("Content-Location", release.URL())
+	}
+
+	script, err := release.Source()
+	if err != nil {
+		log.Println("Failed to load SDK:", err)
+		c.String(http.StatusInternalServerError, "SDK unavailable")
+		return
+	}
+
+	c.Header("ETag", script.ETag)
+	c.Header("X-SDK-Version", release.Name())
+	c.Header("X-SDK-Integrity", script.Integrity)
+
+	if version := release.Version; !version.Deprecated.IsZero() {
+		// RFC 9745 Deprecation, RFC 8594 Sunset
+		c.Header("Deprecation", fmt.Sprintf("@%d", version.Deprecated.Unix()))
+		if !version.Sunset.IsZero() {
+			c.Header("Sunset", version.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if succ, ok := sdk.Lookup(version.Successor); ok {
+			c.Header("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, succ.URL()))
+		}
+	}
+
+	if match := c.GetHeader("If-None-Match"); match != "" && match == script.ETag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/javascript; charset=utf-8", script.Body)
+}
+
+// sdkConfig is the embed configuration returned to the SDK at init.
+type sdkConfig struct {
+	PartnerKey     string            `json:"partnerKey"`
+	MainMode       string            `json:"mainMode,omitempty"`
+	DetailMode     string            `json:"detailMode,omitempty"`
+	DefaultKeyword string            `json:"defaultKeyword,omitempty"`
+	Locale         string            `json:"locale,omitempty"`
+	Theme          map[string]string `json:"theme,omitempty"`
+	Logo           string            `json:"logo,omitempty"`
+	Features       []string          `json:"features"`
+	EnquireFormURL string            `json:"enquireFormUrl"`
+}
+
+// SDKConfigHandler returns the server-side embed configuration for a partner.
+// The SDK fetches it at init so layout changes don't need a partner redeploy.
+func (h *Handler) SDKConfigHandler(c *gin.Context) {
+	c.Header("Access-Control-Allow-Origin", "*")
+
+	partner, ok := h.cfg.Partner(c.Param("partnerKey"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown partner"})
+		return
+	}
+
+	features := partner.Features
+	if features == nil {
+		features = []string{}
+	}
+
+	th := theme.ForPartner(c.Request.Context(), partner)
+
+	c.Header("Cache-Control", "public, max-age=60")
+	c.JSON(http.StatusOK, sdkConfig{
+		PartnerKey:     partner.Key,
+		MainMode:       partner.MainMode,
+		DetailMode:     partner.DetailMode,
+		DefaultKeyword: partner.DefaultKeyword,
+		Locale:         partner.Locale,
+		Theme:          th.Vars(),
+		Logo:           th.Logo,
+		Features:       features,
+		EnquireFormURL: h.enquireFormURL(c),
+	})
+}
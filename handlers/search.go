@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"log"
+	"math"
+	"net/http"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/geo"
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+	"github.com/Tonnie-Exelero/go-ms-kit/tenant"
+
+	"github.com/gin-gonic/gin"
+)
+
+type searchResult struct {
+	ID       int     `json:"id"`
+	Code     string  `json:"code"`
+	Name     string  `json:"name"`
+	Provider string  `json:"provider"`
+	Score    float64 `json:"score"`
+	URL      string  `json:"url"`
+}
+
+// SearchHandler runs a full-text search (q, or the SDK's keyword) over the
+// tenant's catalogue. It returns JSON when asked for with format=json or an
+// Accept header, and otherwise renders like CoursesHandler, so the same
+// filters and ordering apply.
+func (h *Handler) SearchHandler(c *gin.Context) {
+	if c.Query("format") != "json" && c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) != gin.MIMEJSON {
+		h.CoursesHandler(c)
+		return
+	}
+
+	ctx := c.Request.Context()
+	query := h.listQuery(c)
+	if query.Text == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
+
+	hits, err := h.search.Search(ctx, h.tenant(c).Graph, query.Tag, i18n.FromContext(ctx), query.Text)
+	if err != nil {
+		log.Println("Failed to search courses:", err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "search unavailable"})
+		return
+	}
+
+	results := []searchResult{}
+	for _, hit := range hits {
+		if !query.Filter.Matches(hit.Course) || h.access(c, hit.Course) == geo.Restricted {
+			continue
+		}
+		results = append(results, searchResult{
+			ID:       hit.Course.ID,
+			Code:     hit.Course.CourseCode,
+			Name:     hit.Course.CourseName,
+			Provider: hit.Course.Brand.ProviderName,
+			Score:    math.Round(hit.Score*100) / 100,
+			URL:      tenant.Path(ctx, "/courses/"+hit.Course.IDText),
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"query":   query.Text,
+		"total":   len(results),
+		"results": results,
+	})
+}
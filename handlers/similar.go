@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+	"github.com/Tonnie-Exelero/go-ms-kit/recommend"
+	"github.com/Tonnie-Exelero/go-ms-kit/templates"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultRecommendations = 6
+	maxRecommendations     = 12
+)
+
+// SimilarHandler renders cards for the courses most like the given one,
+// picked from the tenant's list for the tag. The detail view loads it into
+// its similar courses section.
+func (h *Handler) SimilarHandler(c *gin.Context) {
+	course, ok := h.routeCourse(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultRecommendations
+	}
+	limit = min(limit, maxRecommendations)
+
+	ctx := c.Request.Context()
+	locale := i18n.FromContext(ctx)
+	query := h.listQuery(c)
+
+	client := h.tenant(c).Graph
+	list, err := client.GetCourseList(ctx, query.Tag, locale)
+	if err != nil {
+		log.Println("Failed to fetch courses:", err)
+		c.String(http.StatusBadGateway, "Failed to fetch courses")
+		return
+	}
+
+	// Rank the whole list, which the recommender can cache, and drop the
+	// courses restricted where the visitor is afterwards
+	pool := recommend.NewPool(client, query.Tag, locale, list)
+	recs, err := h.recommender.Recommend(ctx, course, pool, len(pool.Courses))
+	if err != nil {
+		log.Printf("Failed to recommend courses for %d: %v\n", course.ID, err)
+		c.String(http.StatusInternalServerError, "Failed to recommend courses")
+		return
+	}
+	courses := make([]graph.CourseView, len(recs))
+	for i, rec := range recs {
+		courses[i] = rec.Course
+	}
+	courses = h.visible(c, courses)
+	if len(courses) > limit {
+		courses = courses[:limit]
+	}
+
+	c.Writer.Header().Set("Content-Type", "text/html")
+	err = templates.CourseCards(courses).Render(ctx, c.Writer)
+	if err != nil {
+		log.Println("Failed to render similar courses:", err)
+		c.String(http.StatusInternalServerError, "Template render error: %v", err)
+		return
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+	"github.com/Tonnie-Exelero/go-ms-kit/templates"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UnitHandler renders the details of one of a course's units, found by the
+// code query param, which the subjects accordion loads when the unit is
+// opened. The code isn't a path segment since codes may contain slashes. It
+// returns JSON when asked for with format=json or an Accept header.
+func (h *Handler) UnitHandler(c *gin.Context) {
+	course, ok := h.routeCourse(c)
+	if !ok {
+		return
+	}
+	module, unit, ok := models.FindUnit(course.Modules, c.Query("code"))
+	if !ok {
+		c.String(http.StatusNotFound, "Unit not found")
+		return
+	}
+
+	if c.Query("format") == "json" || c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON {
+		c.JSON(http.StatusOK, gin.H{
+			"course_id":     course.ID,
+			"module":        module.Name,
+			"code":          unit.Code,
+			"name":          unit.Name,
+			"nominal_hours": unit.NominalHours,
+			"type":          unit.Type,
+			"details":       unit.Details,
+		})
+		return
+	}
+
+	c.Writer.Header().Set("Content-Type", "text/html")
+	c.Writer.Header().Add("Vary", "Accept")
+	err := templates.UnitDetail(module, unit).Render(c.Request.Context(), c.Writer)
+	if err != nil {
+		log.Println("Failed to render unit:", err)
+		c.String(http.StatusInternalServerError, "Template render error: %v", err)
+	}
+}
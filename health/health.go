@@ -0,0 +1,111 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check reports whether a single dependency is usable. A nil error means the
+// dependency is healthy.
+type Check func(ctx context.Context) error
+
+// Result is the outcome of running a single named check.
+type Result struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Registry holds the readiness checks for the service.
+type Registry struct {
+	mu     sync.RWMutex
+	checks []namedCheck
+}
+
+// Default is the registry used by the /readyz endpoint.
+var Default = &Registry{}
+
+var draining atomic.Bool
+
+// SetDraining marks the service as shutting down. Readiness fails from then on
+// so load balancers stop routing new traffic while in-flight requests finish.
+func SetDraining() {
+	draining.Store(true)
+}
+
+// Draining reports whether SetDraining has been called.
+func Draining() bool {
+	return draining.Load()
+}
+
+// Register adds a check to the default registry.
+func Register(name string, check Check) {
+	Default.Register(name, check)
+}
+
+// Register adds a named check. Registering the same name twice replaces the
+// earlier check so handlers can safely re-register on reload.
+func (r *Registry) Register(name string, check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, nc := range r.checks {
+		if nc.name == name {
+			r.checks[i].check = check
+			return
+		}
+	}
+	r.checks = append(r.checks, namedCheck{name: name, check: check})
+}
+
+// Run executes every registered check concurrently and returns the results in
+// registration order, along with whether all of them passed.
+func (r *Registry) Run(ctx context.Context) ([]Result, bool) {
+	r.mu.RLock()
+	checks := make([]namedCheck, len(r.checks))
+	copy(checks, r.checks)
+	r.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, nc := range checks {
+		wg.Add(1)
+		go func(i int, nc namedCheck) {
+			defer wg.Done()
+			start := time.Now()
+			err := nc.check(ctx)
+			res := Result{
+				Name:     nc.name,
+				Status:   StatusOK,
+				Duration: time.Since(start).Round(time.Millisecond).String(),
+			}
+			if err != nil {
+				res.Status = StatusFail
+				res.Error = err.Error()
+			}
+			results[i] = res
+		}(i, nc)
+	}
+	wg.Wait()
+
+	healthy := true
+	for _, res := range results {
+		if res.Status != StatusOK {
+			healthy = false
+		}
+	}
+	return results, healthy
+}
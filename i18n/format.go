@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// pluralCategory returns the CLDR plural category for n. Only the rules of
+// the languages we ship are needed.
+func pluralCategory(tag language.Tag, n float64) string {
+	switch b, _ := tag.Base(); b.String() {
+	default: // en, es
+		if n == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
+
+// Number formats n with the locale's grouping and decimal separators.
+func Number(ctx context.Context, n float64) string {
+	p := message.NewPrinter(FromContext(ctx))
+	if n == math.Trunc(n) {
+		return p.Sprint(number.Decimal(int64(n)))
+	}
+	return p.Sprint(number.Decimal(n, number.MaxFractionDigits(2)))
+}
+
+// Money formats an amount in cents of the ISO 4217 currency code with its
+// symbol, e.g. A$ 1,234.50. Unknown currency codes are shown as given.
+func Money(ctx context.Context, cents int64, code string) string {
+	p := message.NewPrinter(FromContext(ctx))
+	amount := float64(cents) / 100
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return code + " " + p.Sprint(number.Decimal(amount, number.Scale(2)))
+	}
+	return p.Sprint(currency.Symbol(unit.Amount(amount)))
+}
+
+// Value returns the display label for a backend code such as
+// ("delivery", "in_class"), or the code itself when there's no label.
+func Value(ctx context.Context, category, code string) string {
+	return ValueIn(FromContext(ctx), category, code)
+}
+
+// ValueIn is Value for an explicit locale.
+func ValueIn(tag language.Tag, category, code string) string {
+	key := category + "." + code
+	if _, ok := lookup(tag, key); !ok {
+		return code
+	}
+	return Translate(tag, key)
+}
+
+// Values labels and joins a list of codes.
+func Values(ctx context.Context, category string, codes []string) string {
+	labels := make([]string, 0, len(codes))
+	for _, code := range codes {
+		labels = append(labels, Value(ctx, category, code))
+	}
+	return strings.Join(labels, ", ")
+}
+
+// Duration renders a course length such as 12 months, pluralised for the
+// locale. Lengths the backend sent as free text are shown as given.
+func Duration(ctx context.Context, d models.Duration) string {
+	key := "duration." + string(d.Unit)
+	if !d.Valid() || !Has(ctx, key) {
+		return d.Raw
+	}
+	return N(ctx, key, d.Length)
+}
+
+// dateLayouts are the start date formats seen from the backend.
+var dateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"02/01/2006",
+	"2 January 2006",
+	"January 2006",
+}
+
+// ParseDate parses a backend date string.
+func ParseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Date formats a date using the locale's "date.long" pattern, where {d}, {m}
+// and {y} are the day, month name and year.
+func Date(ctx context.Context, t time.Time) string {
+	month := T(ctx, "month."+strconv.Itoa(int(t.Month())))
+	return strings.NewReplacer(
+		"{d}", strconv.Itoa(t.Day()),
+		"{m}", month,
+		"{y}", strconv.Itoa(t.Year()),
+	).Replace(T(ctx, "date.long"))
+}
+
+// DateString formats a backend date string, or returns it unchanged if it
+// isn't a date we recognise.
+func DateString(ctx context.Context, s string) string {
+	if t, ok := ParseDate(s); ok {
+		return Date(ctx, t)
+	}
+	return s
+}
@@ -0,0 +1,191 @@
+// Package i18n holds the UI message catalogues, negotiates the request locale
+// and formats numbers, dates and durations for it.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// Default is the locale used when nothing better matches, and the fallback
+// for messages missing from another catalogue.
+var Default = language.English
+
+// entry is a catalogue message: either plain text or plural forms keyed by
+// CLDR category ("one", "other", ...).
+type entry struct {
+	text   string
+	plural map[string]string
+}
+
+func (m *entry) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.text); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &m.plural)
+}
+
+var (
+	catalogues = map[language.Tag]map[string]entry{}
+	supported  []language.Tag
+	matcher    language.Matcher
+)
+
+func init() {
+	entries, err := fs.ReadDir(localeFiles, "locales")
+	if err != nil {
+		panic(err)
+	}
+
+	// The default locale goes first so the matcher falls back to it
+	supported = append(supported, Default)
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), path.Ext(e.Name()))
+		tag := language.MustParse(name)
+
+		data, err := localeFiles.ReadFile("locales/" + e.Name())
+		if err != nil {
+			panic(err)
+		}
+		cat := map[string]entry{}
+		if err := json.Unmarshal(data, &cat); err != nil {
+			panic(fmt.Sprintf("i18n: decoding %s: %v", e.Name(), err))
+		}
+		catalogues[tag] = cat
+		if tag != Default {
+			supported = append(supported, tag)
+		}
+	}
+	matcher = language.NewMatcher(supported)
+}
+
+// Supported returns the locales with a catalogue, default first.
+func Supported() []language.Tag {
+	return supported
+}
+
+// Negotiate picks the best supported locale. Preferences are tried in order,
+// and each may be a single tag ("es") or an Accept-Language header value.
+func Negotiate(preferences ...string) language.Tag {
+	for _, pref := range preferences {
+		if pref == "" {
+			continue
+		}
+		tags, _, err := language.ParseAcceptLanguage(pref)
+		if err != nil || len(tags) == 0 {
+			continue
+		}
+		tag, _, confidence := matcher.Match(tags...)
+		if confidence != language.No {
+			return base(tag)
+		}
+	}
+	return Default
+}
+
+// base strips the extensions the matcher adds (e.g. "es-u-rg-mxzzzz") so the
+// tag can be used as a catalogue key and in <html lang>.
+func base(tag language.Tag) language.Tag {
+	for _, s := range supported {
+		if b, _ := tag.Base(); b == mustBase(s) {
+			return s
+		}
+	}
+	return Default
+}
+
+func mustBase(tag language.Tag) language.Base {
+	b, _ := tag.Base()
+	return b
+}
+
+type contextKey struct{}
+
+// WithLocale returns a copy of ctx carrying the locale.
+func WithLocale(ctx context.Context, tag language.Tag) context.Context {
+	return context.WithValue(ctx, contextKey{}, tag)
+}
+
+// FromContext returns the request locale, or Default.
+func FromContext(ctx context.Context) language.Tag {
+	if tag, ok := ctx.Value(contextKey{}).(language.Tag); ok {
+		return tag
+	}
+	return Default
+}
+
+// T translates key for the context's locale. With args, the message is used
+// as a fmt format string.
+func T(ctx context.Context, key string, args ...any) string {
+	return Translate(FromContext(ctx), key, args...)
+}
+
+// Translate is T for an explicit locale.
+func Translate(tag language.Tag, key string, args ...any) string {
+	msg, ok := lookup(tag, key)
+	if !ok {
+		return key
+	}
+	text := msg.text
+	if msg.plural != nil {
+		text = msg.plural["other"]
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// N translates a plural message for count n. The chosen form receives the
+// locale-formatted number as its only %s argument.
+func N(ctx context.Context, key string, n float64) string {
+	tag := FromContext(ctx)
+	msg, ok := lookup(tag, key)
+	if !ok {
+		return key
+	}
+	if msg.plural == nil {
+		return msg.text
+	}
+	form, ok := msg.plural[pluralCategory(tag, n)]
+	if !ok {
+		form = msg.plural["other"]
+	}
+	if strings.Contains(form, "%s") {
+		return fmt.Sprintf(form, Number(ctx, n))
+	}
+	return form
+}
+
+// Has reports whether key exists in any catalogue reachable from the
+// context's locale.
+func Has(ctx context.Context, key string) bool {
+	_, ok := lookup(FromContext(ctx), key)
+	return ok
+}
+
+func lookup(tag language.Tag, key string) (entry, bool) {
+	if cat, ok := catalogues[tag]; ok {
+		if msg, ok := cat[key]; ok {
+			return msg, true
+		}
+	}
+	if msg, ok := catalogues[Default][key]; ok {
+		if tag != Default {
+			log.Printf("i18n: %q missing for %s, using %s\n", key, tag, Default)
+		}
+		return msg, true
+	}
+	return entry{}, false
+}
@@ -0,0 +1,91 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+
+	"golang.org/x/text/language"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences []string
+		want        language.Tag
+	}{
+		{name: "none", want: Default},
+		{name: "empty", preferences: []string{"", ""}, want: Default},
+		{name: "single tag", preferences: []string{"es"}, want: language.Spanish},
+		{name: "region stripped", preferences: []string{"es-MX"}, want: language.Spanish},
+		{name: "header order", preferences: []string{"es-AR,es;q=0.9,en;q=0.8"}, want: language.Spanish},
+		{name: "header quality", preferences: []string{"en;q=0.5,es;q=0.9"}, want: language.Spanish},
+		{name: "first preference wins", preferences: []string{"en", "es"}, want: language.English},
+		{name: "unsupported falls through", preferences: []string{"ja", "es"}, want: language.Spanish},
+		{name: "invalid falls through", preferences: []string{"not a tag!!", "es"}, want: language.Spanish},
+		{name: "unsupported only", preferences: []string{"ja,zh;q=0.8"}, want: Default},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.preferences...); got != tt.want {
+				t.Errorf("Negotiate(%q) = %v, want %v", tt.preferences, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  language.Tag
+		key  string
+		want string
+	}{
+		{name: "default", tag: language.English, key: "delivery.online", want: "Online"},
+		{name: "translated", tag: language.Spanish, key: "delivery.online", want: "En línea"},
+		{name: "unknown locale uses default", tag: language.Japanese, key: "delivery.online", want: "Online"},
+		{name: "missing key", tag: language.Spanish, key: "no.such.key", want: "no.such.key"},
+		{name: "plural uses other", tag: language.English, key: "duration.month", want: "%s months"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Translate(tt.tag, tt.key); got != tt.want {
+				t.Errorf("Translate(%v, %q) = %q, want %q", tt.tag, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	en := WithLocale(context.Background(), language.English)
+	es := WithLocale(context.Background(), language.Spanish)
+	date := time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "duration one", got: Duration(en, models.Duration{Length: 1, Unit: models.Month}), want: "1 month"},
+		{name: "duration other", got: Duration(es, models.Duration{Length: 12, Unit: models.Month}), want: "12 meses"},
+		{name: "duration fraction", got: Duration(en, models.Duration{Length: 1.5, Unit: models.Year}), want: "1.5 years"},
+		{name: "duration raw", got: Duration(en, models.Duration{Raw: "Up to 2 years"}), want: "Up to 2 years"},
+		{name: "number grouping", got: Number(en, 12345), want: "12,345"},
+		{name: "number grouping es", got: Number(es, 12345.5), want: "12.345,5"},
+		{name: "value labelled", got: Values(es, "delivery", []string{"online", "in_class"}), want: "En línea, Presencial"},
+		{name: "value unlabelled", got: Value(en, "delivery", "by_post"), want: "by_post"},
+		{name: "date", got: Date(en, date), want: "9 February 2026"},
+		{name: "date es", got: Date(es, date), want: "9 de febrero de 2026"},
+		{name: "date string", got: DateString(en, "09/02/2026"), want: "9 February 2026"},
+		{name: "date string unparsed", got: DateString(en, "Monthly"), want: "Monthly"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package leads
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	// attempts is how many times a lead is posted before waiting for the
+	// next sweep.
+	attempts = 4
+	// firstBackoff doubles after each failed attempt.
+	firstBackoff = 2 * time.Second
+	// sweepInterval is how often undelivered leads are retried, which also
+	// picks up leads left over from before a restart.
+	sweepInterval = 10 * time.Minute
+	queueSize     = 64
+)
+
+// Forwarder posts stored leads to a webhook as JSON, retrying with backoff
+// until the webhook accepts them. Each request carries the lead ID in
+// X-Lead-ID, so the receiver can drop the duplicates retries can cause, and
+// an X-Signature HMAC of the body when a secret is set.
+type Forwarder struct {
+	url    string
+	secret []byte
+	store  LeadStore
+	client *http.Client
+	queue  chan Lead
+
+	// delivered holds leads posted but whose receipt the store has yet to
+	// record, so they aren't posted again meanwhile. Recorded leads are
+	// looked up in the store instead.
+	delivered map[string]bool
+}
+
+// NewForwarder returns a Forwarder posting to url and recording deliveries
+// in store.
+func NewForwarder(url string, secret []byte, store LeadStore) *Forwarder {
+	return &Forwarder{
+		url:       url,
+		secret:    secret,
+		store:     store,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		queue:     make(chan Lead, queueSize),
+		delivered: map[string]bool{},
+	}
+}
+
+// Enqueue hands a stored lead over for delivery. It never blocks: when the
+// queue is full the lead waits for the next sweep.
+func (f *Forwarder) Enqueue(lead Lead) {
+	select {
+	case f.queue <- lead:
+	default:
+		log.Printf("Lead queue full, lead %s will be sent on the next retry sweep\n", lead.ID)
+	}
+}
+
+// Run delivers queued leads and sweeps for undelivered ones until ctx is
+// cancelled.
+func (f *Forwarder) Run(ctx context.Context) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	f.sweep(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case lead := <-f.queue:
+			f.deliver(ctx, lead)
+		case <-ticker.C:
+			f.sweep(ctx)
+		}
+	}
+}
+
+func (f *Forwarder) sweep(ctx context.Context) {
+	pending, err := f.store.Pending(ctx)
+	if err != nil {
+		log.Println("Failed to read pending leads:", err)
+		return
+	}
+	for _, lead := range pending {
+		if ctx.Err() != nil {
+			return
+		}
+		f.deliver(ctx, lead)
+	}
+}
+
+// deliver posts the lead, backing off between attempts. A lead that is both
+// queued and swept is only posted once.
+func (f *Forwarder) deliver(ctx context.Context, lead Lead) {
+	if f.delivered[lead.ID] {
+		f.markDelivered(ctx, lead.ID)
+		return
+	}
+	done, err := f.store.Delivered(ctx, lead.ID)
+	if err != nil {
+		// Posting again is safe, as receivers drop repeated IDs
+		log.Printf("Failed to check whether lead %s was delivered: %v\n", lead.ID, err)
+	}
+	if done {
+		return
+	}
+
+	backoff := firstBackoff
+	for attempt := 1; ; attempt++ {
+		err := f.post(ctx, lead)
+		if err == nil {
+			break
+		}
+		if attempt == attempts {
+			log.Printf("Failed to forward lead %s after %d attempts, will retry later: %v\n", lead.ID, attempt, err)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	f.delivered[lead.ID] = true
+	f.markDelivered(ctx, lead.ID)
+}
+
+// markDelivered records the delivery in the store, and forgets the lead once
+// it has. Otherwise the next sweep tries again.
+func (f *Forwarder) markDelivered(ctx context.Context, id string) {
+	if err := f.store.MarkDelivered(ctx, id); err != nil {
+		log.Printf("Forwarded lead %s but failed to record it: %v\n", id, err)
+		return
+	}
+	delete(f.delivered, id)
+}
+
+func (f *Forwarder) post(ctx context.Context, lead Lead) error {
+	body, err := json.Marshal(lead)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Lead-ID", lead.ID)
+	if len(f.secret) > 0 {
+		mac := hmac.New(sha256.New, f.secret)
+		mac.Write(body)
+		req.Header.Set("X-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package leads
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// flakyStore fails to record deliveries while failing is set.
+type flakyStore struct {
+	*FileStore
+	failing bool
+}
+
+func (s *flakyStore) MarkDelivered(ctx context.Context, id string) error {
+	if s.failing {
+		return errors.New("disk full")
+	}
+	return s.FileStore.MarkDelivered(ctx, id)
+}
+
+func TestForwarderDeliversOnce(t *testing.T) {
+	ctx := context.Background()
+	var posts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts.Add(1)
+	}))
+	defer srv.Close()
+
+	store := &flakyStore{FileStore: NewFileStore(filepath.Join(t.TempDir(), "leads.jsonl"))}
+	f := NewForwarder(srv.URL, nil, store)
+	lead := Lead{ID: "a"}
+	if err := store.Save(ctx, lead); err != nil {
+		t.Fatal(err)
+	}
+
+	// Queued and swept: posted once, and nothing left in memory
+	f.deliver(ctx, lead)
+	f.sweep(ctx)
+	f.deliver(ctx, lead)
+	if n := posts.Load(); n != 1 {
+		t.Errorf("posted %d times, want 1", n)
+	}
+	if len(f.delivered) != 0 {
+		t.Errorf("delivered = %v, want empty once recorded", f.delivered)
+	}
+
+	// A receipt that fails to be recorded is remembered, retried on the
+	// next sweep, then forgotten
+	other := Lead{ID: "b"}
+	if err := store.Save(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	store.failing = true
+	f.deliver(ctx, other)
+	f.sweep(ctx)
+	if !f.delivered["b"] {
+		t.Fatal("lead whose receipt failed was forgotten")
+	}
+	store.failing = false
+	f.sweep(ctx)
+	if n := posts.Load(); n != 2 {
+		t.Errorf("posted %d times, want 2", n)
+	}
+	if len(f.delivered) != 0 {
+		t.Errorf("delivered = %v, want empty once recorded", f.delivered)
+	}
+	if pending, _ := store.Pending(ctx); len(pending) != 0 {
+		t.Errorf("Pending() = %v, want none", pending)
+	}
+}
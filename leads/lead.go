@@ -0,0 +1,115 @@
+// Package leads validates enquiries from the native enquiry form, keeps them
+// in a durable store and forwards them to a webhook.
+package leads
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Lead is an enquiry about a course.
+type Lead struct {
+	ID         string    `json:"id"`
+	CourseID   int       `json:"course_id"`
+	CourseCode string    `json:"course_code"`
+	CourseName string    `json:"course_name"`
+	Name       string    `json:"name"`
+	Email      string    `json:"email"`
+	Phone      string    `json:"phone"`
+	Message    string    `json:"message,omitempty"`
+	Consent    bool      `json:"consent"`
+	Locale     string    `json:"locale"`
+	Tenant     string    `json:"tenant"`
+	Partner    string    `json:"partner,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Form is what the learner submitted.
+type Form struct {
+	Name    string
+	Email   string
+	Phone   string
+	Message string
+	Consent bool
+}
+
+// Errors maps a form field to the i18n key of what is wrong with it.
+type Errors map[string]string
+
+const maxMessage = 2000
+
+// Validate checks the form and returns the cleaned-up lead fields. A name, a
+// valid email address, a phone number of 8 to 15 digits and consent to be
+// contacted are required.
+func Validate(f Form) (Lead, Errors) {
+	errs := Errors{}
+	lead := Lead{
+		Name:    strings.TrimSpace(f.Name),
+		Email:   strings.TrimSpace(f.Email),
+		Phone:   normalisePhone(f.Phone),
+		Message: strings.TrimSpace(f.Message),
+		Consent: f.Consent,
+	}
+
+	if lead.Name == "" {
+		errs["name"] = "enquiry.error.name"
+	}
+	if !validEmail(lead.Email) {
+		errs["email"] = "enquiry.error.email"
+	}
+	if digits := strings.TrimPrefix(lead.Phone, "+"); len(digits) < 8 || len(digits) > 15 {
+		errs["phone"] = "enquiry.error.phone"
+	}
+	if len(lead.Message) > maxMessage {
+		errs["message"] = "enquiry.error.message"
+	}
+	if !lead.Consent {
+		errs["consent"] = "enquiry.error.consent"
+	}
+	return lead, errs
+}
+
+// validEmail accepts a bare address with a dotted domain.
+func validEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s {
+		return false
+	}
+	_, domain, _ := strings.Cut(s, "@")
+	return strings.Contains(domain, ".") && !strings.HasSuffix(domain, ".")
+}
+
+// normalisePhone drops spacing and punctuation, keeping a leading +. Letters
+// are kept so they fail validation rather than being silently removed.
+func normalisePhone(s string) string {
+	s = strings.TrimSpace(s)
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || strings.ContainsRune("()-.", r):
+		default:
+			b.WriteRune(r)
+		}
+	}
+	out := b.String()
+	for _, r := range strings.TrimPrefix(out, "+") {
+		if r < '0' || r > '9' {
+			// Makes the length check fail
+			return ""
+		}
+	}
+	return out
+}
+
+// NewID returns a random lead ID.
+func NewID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,90 @@
+package leads
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	valid := Form{
+		Name:    "Ada Lovelace",
+		Email:   "ada@example.com",
+		Phone:   "0412 345 678",
+		Message: "When does it start?",
+		Consent: true,
+	}
+	with := func(change func(*Form)) Form {
+		f := valid
+		change(&f)
+		return f
+	}
+
+	tests := []struct {
+		name      string
+		form      Form
+		wantErrs  Errors
+		wantPhone string
+	}{
+		{name: "valid", form: valid, wantErrs: Errors{}, wantPhone: "0412345678"},
+		{
+			name:      "international phone with punctuation",
+			form:      with(func(f *Form) { f.Phone = " +61 (4) 1234-5678 " }),
+			wantErrs:  Errors{},
+			wantPhone: "+61412345678",
+		},
+		{name: "phone with dots", form: with(func(f *Form) { f.Phone = "04.1234.5678" }), wantErrs: Errors{}, wantPhone: "0412345678"},
+		{name: "phone 8 digits", form: with(func(f *Form) { f.Phone = "1234 5678" }), wantErrs: Errors{}, wantPhone: "12345678"},
+		{name: "phone 15 digits", form: with(func(f *Form) { f.Phone = "+123456789012345" }), wantErrs: Errors{}, wantPhone: "+123456789012345"},
+		{name: "phone 7 digits", form: with(func(f *Form) { f.Phone = "1234567" }), wantErrs: Errors{"phone": "enquiry.error.phone"}, wantPhone: "1234567"},
+		{name: "phone 16 digits", form: with(func(f *Form) { f.Phone = "1234567890123456" }), wantErrs: Errors{"phone": "enquiry.error.phone"}, wantPhone: "1234567890123456"},
+		{name: "phone with letters", form: with(func(f *Form) { f.Phone = "0412 ABC 678" }), wantErrs: Errors{"phone": "enquiry.error.phone"}},
+		{name: "plus inside phone", form: with(func(f *Form) { f.Phone = "0412+345678" }), wantErrs: Errors{"phone": "enquiry.error.phone"}},
+		{name: "blank name", form: with(func(f *Form) { f.Name = "   " }), wantErrs: Errors{"name": "enquiry.error.name"}, wantPhone: "0412345678"},
+		{name: "email without domain dot", form: with(func(f *Form) { f.Email = "ada@localhost" }), wantErrs: Errors{"email": "enquiry.error.email"}, wantPhone: "0412345678"},
+		{name: "email with trailing dot", form: with(func(f *Form) { f.Email = "ada@example." }), wantErrs: Errors{"email": "enquiry.error.email"}, wantPhone: "0412345678"},
+		{name: "email with display name", form: with(func(f *Form) { f.Email = "Ada <ada@example.com>" }), wantErrs: Errors{"email": "enquiry.error.email"}, wantPhone: "0412345678"},
+		{name: "email padded", form: with(func(f *Form) { f.Email = " ada@example.com " }), wantErrs: Errors{}, wantPhone: "0412345678"},
+		{name: "message at limit", form: with(func(f *Form) { f.Message = strings.Repeat("a", maxMessage) }), wantErrs: Errors{}, wantPhone: "0412345678"},
+		{name: "message too long", form: with(func(f *Form) { f.Message = strings.Repeat("a", maxMessage+1) }), wantErrs: Errors{"message": "enquiry.error.message"}, wantPhone: "0412345678"},
+		{name: "no consent", form: with(func(f *Form) { f.Consent = false }), wantErrs: Errors{"consent": "enquiry.error.consent"}, wantPhone: "0412345678"},
+		{
+			name: "empty",
+			form: Form{},
+			wantErrs: Errors{
+				"name":    "enquiry.error.name",
+				"email":   "enquiry.error.email",
+				"phone":   "enquiry.error.phone",
+				"consent": "enquiry.error.consent",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lead, errs := Validate(tt.form)
+			if !maps.Equal(errs, tt.wantErrs) {
+				t.Errorf("Validate() errors = %v, want %v", errs, tt.wantErrs)
+			}
+			if lead.Phone != tt.wantPhone {
+				t.Errorf("Validate() phone = %q, want %q", lead.Phone, tt.wantPhone)
+			}
+		})
+	}
+}
+
+func TestValidateTrims(t *testing.T) {
+	lead, errs := Validate(Form{
+		Name:    "  Ada  ",
+		Email:   " ada@example.com\n",
+		Phone:   "0412345678",
+		Message: "\n Hello \n",
+		Consent: true,
+	})
+	if len(errs) != 0 {
+		t.Fatalf("Validate() errors = %v, want none", errs)
+	}
+	if lead.Name != "Ada" || lead.Email != "ada@example.com" || lead.Message != "Hello" {
+		t.Errorf("Validate() = %+v, want trimmed name, email and message", lead)
+	}
+}
@@ -0,0 +1,173 @@
+package leads
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// LeadStore keeps leads until they have been delivered.
+type LeadStore interface {
+	// Save records a new lead durably before it is acknowledged.
+	Save(ctx context.Context, lead Lead) error
+	// Pending returns the leads not yet delivered, oldest first.
+	Pending(ctx context.Context) ([]Lead, error)
+	// MarkDelivered records that the lead reached the webhook.
+	MarkDelivered(ctx context.Context, id string) error
+	// Delivered reports whether the lead's delivery has been recorded.
+	Delivered(ctx context.Context, id string) (bool, error)
+}
+
+// record is a line of the leads file: a new lead, or a delivery receipt.
+type record struct {
+	Lead        *Lead     `json:"lead,omitempty"`
+	DeliveredID string    `json:"delivered,omitempty"`
+	At          time.Time `json:"at"`
+}
+
+// FileStore appends leads and delivery receipts to a JSON Lines file, syncing
+// each write, so leads survive crashes and restarts and the file doubles as
+// an audit log. The undelivered leads are kept in memory, and each read only
+// scans the lines appended since the last.
+type FileStore struct {
+	path string
+
+	mu      sync.Mutex
+	offset  int64           // end of the last complete line read
+	order   []string        // IDs of pending leads, oldest first
+	pending map[string]Lead // undelivered leads by ID
+}
+
+// NewFileStore returns a store appending to path. The file and its directory
+// are created on the first write.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path, pending: map[string]Lead{}}
+}
+
+// Save implements LeadStore.
+func (s *FileStore) Save(ctx context.Context, lead Lead) error {
+	return s.append(record{Lead: &lead, At: time.Now()})
+}
+
+// MarkDelivered implements LeadStore.
+func (s *FileStore) MarkDelivered(ctx context.Context, id string) error {
+	return s.append(record{DeliveredID: id, At: time.Now()})
+}
+
+// Pending implements LeadStore.
+func (s *FileStore) Pending(ctx context.Context) ([]Lead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.catchUp(); err != nil {
+		return nil, err
+	}
+
+	// Drop delivered IDs from the order as well, so it stays as small as
+	// the pending set
+	var leads []Lead
+	order := s.order[:0]
+	for _, id := range s.order {
+		if lead, ok := s.pending[id]; ok {
+			leads = append(leads, lead)
+			order = append(order, id)
+		}
+	}
+	s.order = order
+	return leads, nil
+}
+
+// Delivered implements LeadStore. Every lead is saved before it is handed
+// over for delivery, so once the file is read a lead that isn't pending has
+// been delivered.
+func (s *FileStore) Delivered(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.catchUp(); err != nil {
+		return false, err
+	}
+	_, ok := s.pending[id]
+	return !ok, nil
+}
+
+// catchUp reads the records appended since the last read. The caller holds
+// s.mu.
+func (s *FileStore) catchUp() error {
+	f, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil && info.Size() < s.offset {
+		// The file was replaced, e.g. archived; start over
+		s.offset, s.order, s.pending = 0, nil, map[string]Lead{}
+	}
+	if _, err := f.Seek(s.offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// A partial line is left for the next read
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.offset += int64(len(line))
+
+		var rec record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			// Most likely a line torn by a crash mid-write; the others are
+			// still good
+			log.Printf("%s: skipping malformed lead record at byte %d: %v\n", s.path, s.offset-int64(len(line)), err)
+			continue
+		}
+		switch {
+		case rec.Lead != nil:
+			s.order = append(s.order, rec.Lead.ID)
+			s.pending[rec.Lead.ID] = *rec.Lead
+		case rec.DeliveredID != "":
+			delete(s.pending, rec.DeliveredID)
+		}
+	}
+}
+
+func (s *FileStore) append(r record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,102 @@
+package leads
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func pendingIDs(t *testing.T, s *FileStore) []string {
+	t.Helper()
+	leads, err := s.Pending(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, lead := range leads {
+		ids = append(ids, lead.ID)
+	}
+	return ids
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "leads.jsonl")
+	s := NewFileStore(path)
+
+	if got := pendingIDs(t, s); got != nil {
+		t.Fatalf("Pending() before any write = %v, want none", got)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if err := s.Save(ctx, Lead{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.MarkDelivered(ctx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pendingIDs(t, s), []string{"a", "c"}; !slices.Equal(got, want) {
+		t.Errorf("Pending() = %v, want %v", got, want)
+	}
+
+	// Only the lines appended since are read, and a partial line waits
+	// until it is complete
+	if err := s.Save(ctx, Lead{ID: "d"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.MarkDelivered(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"delivered":"c"`)
+	f.Close()
+	if got, want := pendingIDs(t, s), []string{"c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("Pending() after appends = %v, want %v", got, want)
+	}
+	f, err = os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`,"at":"2025-01-01T00:00:00Z"}` + "\n")
+	f.Close()
+
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"a", true},
+		{"b", true},
+		{"c", true},
+		{"d", false},
+	}
+	for _, tt := range tests {
+		got, err := s.Delivered(ctx, tt.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Delivered(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+
+	// A fresh store, as after a restart, reads the whole file
+	if got, want := pendingIDs(t, NewFileStore(path)), []string{"d"}; !slices.Equal(got, want) {
+		t.Errorf("Pending() after restart = %v, want %v", got, want)
+	}
+
+	// A replaced file is read from the start
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(ctx, Lead{ID: "e"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pendingIDs(t, s), []string{"e"}; !slices.Equal(got, want) {
+		t.Errorf("Pending() after the file was replaced = %v, want %v", got, want)
+	}
+}
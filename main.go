@@ -1,35 +1,146 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"maps"
+	"net/http"
 	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/Tonnie-Exelero/go-ms-kit/assets"
+	"github.com/Tonnie-Exelero/go-ms-kit/config"
+	"github.com/Tonnie-Exelero/go-ms-kit/handlers"
+	"github.com/Tonnie-Exelero/go-ms-kit/health"
+	"github.com/Tonnie-Exelero/go-ms-kit/middleware"
 	"github.com/Tonnie-Exelero/go-ms-kit/routes"
+	"github.com/Tonnie-Exelero/go-ms-kit/tenant"
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
 )
 
 func main() {
-	// Load environment variables from .env
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
+	// Load and validate configuration from .env, environment and CONFIG_FILE
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
 	}
+	log.Println("Configuration loaded:", cfg)
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
+	tenants := tenant.NewRegistry(cfg)
+
+	// Cancelled on SIGINT/SIGTERM; everything long-running hangs off this
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	// Create a Gin router
 	router := gin.Default()
+	router.Use(middleware.CORS(), middleware.Locale())
 
-	// Serve static assets (CSS, JS, images)
-	router.Static("/assets", "./assets")
+	// Serve static assets (CSS, JS, images) from the binary
+	router.GET(assets.URLPrefix+"*filepath", gin.WrapH(http.StripPrefix(assets.URLPrefix, assets.Handler())))
 
 	// Setup application routes
-	routes.SetupRoutes(router)
+	h := handlers.New(cfg, tenants)
+	routes.SetupRoutes(router, h)
+
+	// Background workers: one cache refresher per distinct backend, warming
+	// the default tags of the tenants it serves
+	var workers sync.WaitGroup
+	for _, client := range tenants.Clients() {
+		tags := map[string]bool{}
+		for _, t := range tenants.All() {
+			if t.Graph == client {
+				tags[t.DefaultTag] = true
+			}
+		}
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			client.RefreshLoop(ctx, client.RefreshInterval(), slices.Collect(maps.Keys(tags))...)
+		}()
+	}
+
+	// Lead delivery to the enquiry webhook, if one is configured
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		h.ForwardLeads(ctx)
+	}()
 
-	log.Printf("Server running on port %s", port)
-	router.Run(":" + port)
+	srv := &http.Server{
+		Addr:         ":" + cfg.Port,
+		Handler:      middleware.Tenant(tenants, router),
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("Server running on port %s", cfg.Port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+		close(serveErr)
+	}()
+
+	failed := false
+	select {
+	case err := <-serveErr:
+		if err != nil {
+			log.Println("Server failed:", err)
+			failed = true
+		}
+	case <-ctx.Done():
+		log.Println("Shutdown signal received, draining requests...")
+	}
+	stop()
+
+	// Fail readiness first, and keep serving while the load balancer notices
+	// and stops sending new traffic
+	health.SetDraining()
+	if !failed {
+		time.Sleep(cfg.Server.DrainDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Println("Forced shutdown, some requests were cut off:", err)
+	}
+
+	// Wait for background workers, but not past the shutdown deadline
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-shutdownCtx.Done():
+		log.Println("Background workers did not stop before the deadline")
+	}
+
+	log.Println("Server stopped")
+	flushLogs()
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// flushLogs syncs the application and Gin request logs when they are backed by
+// files so the last lines aren't lost when the container exits.
+func flushLogs() {
+	for _, w := range []any{log.Writer(), gin.DefaultWriter, gin.DefaultErrorWriter} {
+		if f, ok := w.(*os.File); ok {
+			_ = f.Sync()
+		}
+	}
 }
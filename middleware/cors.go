@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/auth"
+	"github.com/Tonnie-Exelero/go-ms-kit/tenant"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Request headers the embed sends cross-origin.
+var corsAllowHeaders = strings.Join([]string{
+	"Content-Type",
+	"HX-Request",
+	"HX-Current-URL",
+	"HX-Target",
+	"HX-Trigger",
+	"HX-Trigger-Name",
+	auth.CSRFHeader,
+	tenant.APIKeyHeader,
+	tenant.PartnerHeader,
+	LocaleHeader,
+}, ", ")
+
+// CORS allows browsers on the tenant's allowed origins to call the service
+// and answers preflight requests.
+func CORS() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		t := tenant.FromContext(c.Request.Context())
+		if origin == "" || t == nil || !t.AllowsOrigin(origin) {
+			if c.Request.Method == http.MethodOptions && origin != "" {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		c.Header("Access-Control-Allow-Origin", origin)
+		// The session and saved list cookies ride along with embed requests,
+		// but only from origins listed by name rather than matched by "*"
+		if slices.Contains(t.AllowedOrigins, origin) {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+		c.Writer.Header().Add("Vary", "Origin")
+		c.Header("Access-Control-Expose-Headers", "HX-Trigger, HX-Redirect, HX-Push-Url")
+
+		if c.Request.Method == http.MethodOptions {
+			c.Header("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			c.Header("Access-Control-Allow-Headers", corsAllowHeaders)
+			c.Header("Access-Control-Max-Age", "600")
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleHeader carries the locale chosen in the SDK configuration.
+const LocaleHeader = "X-MF-Locale"
+
+// Locale negotiates the request locale and stores it in the request context.
+// An explicit lang query param wins, then the SDK's locale header, then the
+// browser's Accept-Language.
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tag := i18n.Negotiate(
+			c.Query("lang"),
+			c.GetHeader(LocaleHeader),
+			c.GetHeader("Accept-Language"),
+		)
+		c.Request = c.Request.WithContext(i18n.WithLocale(c.Request.Context(), tag))
+
+		c.Header("Content-Language", tag.String())
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
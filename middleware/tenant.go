@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/tenant"
+)
+
+// Tenant resolves the tenant for each request, strips its path prefix and
+// stores it in the request context. It wraps the router rather than running
+// as Gin middleware because the prefix must be removed before routing.
+func Tenant(reg *tenant.Registry, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t, path := reg.Resolve(r)
+		if path != r.URL.Path {
+			r.URL.Path = path
+			r.URL.RawPath = ""
+		}
+		next.ServeHTTP(w, r.WithContext(tenant.WithTenant(r.Context(), t)))
+	})
+}
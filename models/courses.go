@@ -33,6 +33,8 @@ type Course struct {
     CourseModule                        json.RawMessage `json:"course_module"`
     TopPanel                            json.RawMessage `json:"top_panel"`
     Testimonials                        json.RawMessage `json:"testimonies"`
+    Fees                                json.RawMessage `json:"fees"`
+    Eligibility                         json.RawMessage `json:"eligibility"`
     StartDate                           string   `json:"start_date"`
     Frequency                           []string `json:"frequency"`
     DurationLength                      string   `json:"duration_length"`
@@ -41,6 +43,40 @@ type Course struct {
     Partner                             Partner  `json:"partner"`
     BrandID                             int      `json:"brand_id"`
     Brand                               Brand    `json:"brand"`
+    Translations                        Translations `json:"translations"`
+}
+
+// Translations holds the localised copies returned alongside a course. The
+// backend only sends the rows for the requested locale.
+type Translations struct {
+    Edges []struct {
+        Node CourseTranslation `json:"node"`
+    } `json:"edges"`
+}
+
+// CourseTranslation is the localised copy of a course for one locale. Empty
+// fields fall back to the default-language value.
+type CourseTranslation struct {
+    Locale                              string `json:"locale"`
+    CourseName                          string `json:"course_name"`
+    DeliveryLongText                    string `json:"delivery_long_text"`
+    Overview                            string `json:"overview"`
+    WhoIsItFor                          string `json:"who_is_it_for"`
+    WhatYoullLearn                      string `json:"what_youll_learn"`
+    DurationAndStudyLoad                string `json:"duration_and_study_load"`
+    JobOutcomes                         string `json:"job_outcomes"`
+    EntryRequirements                   string `json:"entry_requirements"`
+    CourseFeatures                      string `json:"course_features"`
+    WorkPlacement                       string `json:"work_placement"`
+    RecognitionOfPriorLearning          string `json:"recognition_of_prior_learning"`
+    Assessment                          string `json:"assessment"`
+    FurtherStudyAndEducationPathways    string `json:"further_study_and_education_pathways"`
+    ProfessionalRecognition             string `json:"professional_recognition"`
+    Materials                           string `json:"materials"`
+    PaymentOptions                      string `json:"payment_options"`
+    AdditionalInformation               string `json:"additional_information"`
+    CourseModule                        json.RawMessage `json:"course_module"`
+    TopPanel                            json.RawMessage `json:"top_panel"`
 }
 
 type Brand struct {
@@ -49,6 +85,9 @@ type Brand struct {
     AboutProvider string `json:"about_provider"`
     Logo          string `json:"logo"`
     RTOCode       string `json:"rto_code"`
+    // Theme holds the brand's theme tokens, like a tenant's; only the
+    // course detail query selects it.
+    Theme         json.RawMessage `json:"theme"`
 }
 
 type Partner struct {
@@ -0,0 +1,17 @@
+package models
+
+// Eligibility is a course's structured entry rules, from the backend's
+// eligibility column. Zero values mean there is no rule of that kind.
+type Eligibility struct {
+	MinAge int `json:"min_age"`
+	// Qualification is the lowest prior qualification accepted, e.g.
+	// year_12 (see eligibility.Qualifications).
+	Qualification string `json:"qualification"`
+	// English is the lowest CEFR level of English accepted, e.g. B2.
+	English string `json:"english"`
+	// Residency lists the residency statuses accepted, e.g. citizen or
+	// permanent_resident.
+	Residency []string `json:"residency"`
+	// RPL is whether the course takes recognition of prior learning.
+	RPL bool `json:"rpl"`
+}
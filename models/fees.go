@@ -0,0 +1,31 @@
+package models
+
+// Payment plan frequencies.
+const (
+	Weekly      = "weekly"
+	Fortnightly = "fortnightly"
+	Monthly     = "monthly"
+)
+
+// Fees is a course's structured pricing, from the backend's fees column.
+// Amounts are in the currency's major unit, e.g. dollars.
+type Fees struct {
+	// Currency is an ISO 4217 code such as AUD.
+	Currency string        `json:"currency"`
+	Upfront  float64       `json:"upfront"`
+	Deposit  float64       `json:"deposit"`
+	Plans    []PaymentPlan `json:"plans"`
+}
+
+// Valid reports whether there is a price to calculate with.
+func (f Fees) Valid() bool {
+	return f.Currency != "" && f.Upfront > 0
+}
+
+// PaymentPlan pays what is left after the deposit in equal instalments.
+type PaymentPlan struct {
+	Frequency   string `json:"frequency"`
+	Instalments int    `json:"instalments"`
+	// AdminFee is added to the price when paying by this plan.
+	AdminFee float64 `json:"admin_fee"`
+}
@@ -0,0 +1,52 @@
+package models
+
+// UnitType says whether a unit must be studied or can be picked.
+type UnitType string
+
+const (
+	Core     UnitType = "core"
+	Elective UnitType = "elective"
+)
+
+// Module is a group of units in a course's structure, from the backend's
+// course_module column.
+type Module struct {
+	// Name may be empty for units listed before any module.
+	Name string
+	// Electives is how many of the module's elective units a learner picks.
+	Electives int
+	Units     []Unit
+}
+
+// Unit is a unit of competency or subject within a module.
+type Unit struct {
+	Code         string
+	Name         string
+	NominalHours float64
+	Type         UnitType
+	// Details is sanitised HTML.
+	Details string
+}
+
+// ElectiveUnits counts the module's elective units to pick from.
+func (m Module) ElectiveUnits() int {
+	n := 0
+	for _, u := range m.Units {
+		if u.Type == Elective {
+			n++
+		}
+	}
+	return n
+}
+
+// FindUnit returns the unit with code and the module it belongs to.
+func FindUnit(modules []Module, code string) (Module, Unit, bool) {
+	for _, m := range modules {
+		for _, u := range m.Units {
+			if u.Code != "" && u.Code == code {
+				return m, u, true
+			}
+		}
+	}
+	return Module{}, Unit{}, false
+}
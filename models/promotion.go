@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Promotion kinds. Notices render as the bullet list at the top of the detail
+// view; a highlight is the single call-to-action banner beneath it.
+const (
+	PromotionNotice    = "notice"
+	PromotionHighlight = "highlight"
+)
+
+// Promotion is a marketing message shown on course detail views. Empty
+// targeting lists match everything; a zero StartsAt or EndsAt leaves that side
+// of the window open.
+type Promotion struct {
+	ID        string    `json:"id" yaml:"id"`
+	Kind      string    `json:"kind" yaml:"kind"`
+	Text      string    `json:"text" yaml:"text"`
+	Priority  int       `json:"priority" yaml:"priority"`
+	StartsAt  time.Time `json:"starts_at" yaml:"starts_at"`
+	EndsAt    time.Time `json:"ends_at" yaml:"ends_at"`
+	CourseIDs []int     `json:"course_ids" yaml:"course_ids"`
+	BrandIDs  []int     `json:"brand_ids" yaml:"brand_ids"`
+	Tenants   []string  `json:"tenants" yaml:"tenants"`
+	Levels    []string  `json:"levels" yaml:"levels"`
+	Delivery  []string  `json:"delivery" yaml:"delivery"`
+	// Discount is a percentage taken off the course fee while the
+	// promotion runs.
+	Discount float64 `json:"discount" yaml:"discount"`
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// DurationUnit is a normalised course length unit.
+type DurationUnit string
+
+const (
+	Hour  DurationUnit = "hour"
+	Day   DurationUnit = "day"
+	Week  DurationUnit = "week"
+	Month DurationUnit = "month"
+	Year  DurationUnit = "year"
+)
+
+// Duration is a course length such as 12 months. Raw keeps the backend text
+// for lengths that couldn't be parsed, so they can still be shown as given.
+type Duration struct {
+	Length float64
+	Unit   DurationUnit
+	Raw    string
+}
+
+// Valid reports whether the duration was parsed into a length and unit.
+func (d Duration) Valid() bool {
+	return d.Unit != "" && d.Length > 0
+}
+
+// LengthText formats the length without trailing zeros ("12", "1.5").
+func (d Duration) LengthText() string {
+	return strconv.FormatFloat(d.Length, 'f', -1, 64)
+}
+
+// After returns the time the duration ends when started at t. Fractional
+// months and years are rounded to whole days.
+func (d Duration) After(t time.Time) time.Time {
+	switch d.Unit {
+	case Hour:
+		return t.Add(time.Duration(d.Length * float64(time.Hour)))
+	case Day:
+		return t.AddDate(0, 0, int(d.Length+0.5))
+	case Week:
+		return t.AddDate(0, 0, int(d.Length*7+0.5))
+	case Month:
+		return t.AddDate(0, 0, int(d.Length*30.44+0.5))
+	case Year:
+		return t.AddDate(0, 0, int(d.Length*365.25+0.5))
+	}
+	return t
+}
+
+// Approx is the duration as a time span, used for ordering courses by length.
+func (d Duration) Approx() time.Duration {
+	var zero time.Time
+	return d.After(zero).Sub(zero)
+}
+
+// Intake is a single start date of a course. End is estimated from the course
+// duration and is zero when the duration is unknown.
+type Intake struct {
+	Start time.Time
+	End   time.Time
+}
+
+// DateKey identifies the intake in URLs (YYYY-MM-DD).
+func (i Intake) DateKey() string {
+	return i.Start.Format("2006-01-02")
+}
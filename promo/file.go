@@ -0,0 +1,66 @@
+package promo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileSource reads promotions from a YAML (or JSON) content file and reloads
+// it whenever the file's modification time changes.
+type FileSource struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	promos  []models.Promotion
+}
+
+// NewFileSource returns a source backed by path. A missing file means no
+// promotions rather than an error.
+func NewFileSource(path string) *FileSource {
+	return &FileSource{path: path}
+}
+
+type contentFile struct {
+	Promotions []models.Promotion `yaml:"promotions"`
+}
+
+// Promotions returns the file's promotions, re-reading it if it changed. If a
+// reload fails the previous contents keep being served.
+func (s *FileSource) Promotions(ctx context.Context) ([]models.Promotion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.promos, s.modTime = nil, time.Time{}
+		return nil, nil
+	}
+	if err != nil {
+		return s.promos, err
+	}
+	if info.ModTime().Equal(s.modTime) {
+		return s.promos, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return s.promos, err
+	}
+	var cf contentFile
+	if err := yaml.Unmarshal(data, &cf); err != nil {
+		return s.promos, fmt.Errorf("decoding %s: %w", s.path, err)
+	}
+
+	s.promos, s.modTime = cf.Promotions, info.ModTime()
+	return s.promos, nil
+}
@@ -0,0 +1,21 @@
+package promo
+
+import (
+	"context"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+)
+
+type graphSource struct {
+	client *graph.Client
+}
+
+// FromGraph returns a source reading promotions from a tenant's backend.
+func FromGraph(client *graph.Client) Source {
+	return graphSource{client: client}
+}
+
+func (s graphSource) Promotions(ctx context.Context) ([]models.Promotion, error) {
+	return s.client.GetPromotions(ctx)
+}
@@ -0,0 +1,101 @@
+// Package promo loads promotional banners and picks the ones that apply to a
+// course, so campaigns can change without a code deploy.
+package promo
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+)
+
+// Source supplies the current set of promotions.
+type Source interface {
+	Promotions(ctx context.Context) ([]models.Promotion, error)
+}
+
+// Banners are the promotions to render on one detail view.
+type Banners struct {
+	Notices   []models.Promotion
+	Highlight *models.Promotion
+	// Discount is the matched promotion with the largest fee discount, if
+	// any.
+	Discount *models.Promotion
+}
+
+// Empty reports whether there is nothing to render.
+func (b Banners) Empty() bool {
+	return len(b.Notices) == 0 && b.Highlight == nil
+}
+
+// Select returns the promotions that are live at now and target the course
+// and tenant, highest priority first. Only the top highlight is kept, and
+// only the largest discount applies.
+func Select(promos []models.Promotion, course graph.CourseView, tenantID string, now time.Time) Banners {
+	var matched []models.Promotion
+	for _, p := range promos {
+		if active(p, now) && targets(p, course, tenantID) {
+			matched = append(matched, p)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].Priority > matched[j].Priority
+	})
+
+	var b Banners
+	for i, p := range matched {
+		if p.Discount > 0 && (b.Discount == nil || p.Discount > b.Discount.Discount) {
+			b.Discount = &matched[i]
+		}
+		switch p.Kind {
+		case models.PromotionHighlight:
+			if b.Highlight == nil {
+				b.Highlight = &matched[i]
+			}
+		default:
+			b.Notices = append(b.Notices, p)
+		}
+	}
+	return b
+}
+
+func active(p models.Promotion, now time.Time) bool {
+	if !p.StartsAt.IsZero() && now.Before(p.StartsAt) {
+		return false
+	}
+	if !p.EndsAt.IsZero() && !now.Before(p.EndsAt) {
+		return false
+	}
+	return p.Text != ""
+}
+
+func targets(p models.Promotion, course graph.CourseView, tenantID string) bool {
+	if len(p.CourseIDs) > 0 && !slices.Contains(p.CourseIDs, course.ID) {
+		return false
+	}
+	if len(p.BrandIDs) > 0 && !slices.Contains(p.BrandIDs, course.BrandID) {
+		return false
+	}
+	if len(p.Tenants) > 0 && !slices.Contains(p.Tenants, tenantID) {
+		return false
+	}
+	if len(p.Levels) > 0 && !overlaps(p.Levels, course.Level) {
+		return false
+	}
+	if len(p.Delivery) > 0 && !overlaps(p.Delivery, course.Delivery) {
+		return false
+	}
+	return true
+}
+
+func overlaps(a, b []string) bool {
+	for _, v := range a {
+		if slices.Contains(b, v) {
+			return true
+		}
+	}
+	return false
+}
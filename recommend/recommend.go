@@ -0,0 +1,47 @@
+// Package recommend suggests courses related to the one a learner is viewing.
+package recommend
+
+import (
+	"context"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+
+	"golang.org/x/text/language"
+)
+
+// Recommender picks the courses most related to course from the pool, best
+// first. Implementations never return course itself.
+type Recommender interface {
+	Recommend(ctx context.Context, course graph.CourseView, pool Pool, limit int) ([]Recommendation, error)
+}
+
+// Pool is a course list to recommend from. Recommenders may cache what they
+// derive from it under its key.
+type Pool struct {
+	Courses []graph.CourseView
+	Key     PoolKey
+}
+
+// PoolKey identifies a cached course list: its client, tag, locale and cache
+// generation.
+type PoolKey struct {
+	Client     *graph.Client
+	Tag        string
+	Locale     language.Tag
+	Generation uint64
+}
+
+// NewPool returns the pool for a client's list for tag in locale.
+func NewPool(client *graph.Client, tag string, locale language.Tag, list graph.CourseList) Pool {
+	return Pool{
+		Courses: list.Courses,
+		Key:     PoolKey{Client: client, Tag: tag, Locale: locale, Generation: list.Generation},
+	}
+}
+
+// Recommendation is a related course and how related it is. Scores are only
+// comparable between results of the same Recommender.
+type Recommendation struct {
+	Course graph.CourseView
+	Score  float64
+}
@@ -0,0 +1,228 @@
+package recommend
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/i18n"
+	"github.com/Tonnie-Exelero/go-ms-kit/search"
+
+	"golang.org/x/text/language"
+)
+
+// Weights set how much each kind of overlap counts. Every signal is between
+// 0 and 1 before weighting.
+type Weights struct {
+	Level    float64
+	Delivery float64
+	Brand    float64
+	Subjects float64
+	Text     float64
+}
+
+// DefaultWeights favour what the courses teach (subjects and overview) over
+// how they are run.
+var DefaultWeights = Weights{Level: 2, Delivery: 1, Brand: 1, Subjects: 2, Text: 3}
+
+// minScore drops candidates that only share incidental traits, such as a
+// delivery mode.
+const minScore = 0.15
+
+// modelCacheSize bounds the per-pool models kept; the cache is reset when
+// full, since refreshed lists replace old ones.
+const modelCacheSize = 64
+
+// Similar recommends by content: shared levels, delivery modes, brand and
+// subjects, and TF-IDF cosine similarity of the overviews. Text is analysed
+// like search does, in the request locale.
+type Similar struct {
+	weights Weights
+
+	mu     sync.Mutex
+	models map[modelKey]*model
+}
+
+// NewSimilar returns a content-based Recommender using weights.
+func NewSimilar(weights Weights) *Similar {
+	return &Similar{weights: weights, models: map[modelKey]*model{}}
+}
+
+// Recommend implements Recommender.
+func (s *Similar) Recommend(ctx context.Context, course graph.CourseView, pool Pool, limit int) ([]Recommendation, error) {
+	candidates := pool.Courses
+	if len(candidates) == 0 || limit <= 0 {
+		return nil, nil
+	}
+	m := s.model(pool, i18n.FromContext(ctx))
+	target, ok := m.profiles[course.ID]
+	if !ok {
+		target = m.profile(course)
+	}
+
+	total := s.weights.Level + s.weights.Delivery + s.weights.Brand + s.weights.Subjects + s.weights.Text
+	if total == 0 {
+		return nil, nil
+	}
+
+	var recs []Recommendation
+	for _, candidate := range candidates {
+		if candidate.ID == course.ID {
+			continue
+		}
+		p := m.profiles[candidate.ID]
+		score := s.weights.Level*jaccard(course.Level, candidate.Level) +
+			s.weights.Delivery*jaccard(course.Delivery, candidate.Delivery) +
+			s.weights.Subjects*jaccard(target.subjects, p.subjects) +
+			s.weights.Text*cosine(target.text, p.text)
+		if course.BrandID != 0 && course.BrandID == candidate.BrandID {
+			score += s.weights.Brand
+		}
+		if score /= total; score >= minScore {
+			recs = append(recs, Recommendation{Course: candidate, Score: score})
+		}
+	}
+
+	slices.SortFunc(recs, func(a, b Recommendation) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Course.CourseName, b.Course.CourseName)
+	})
+	if len(recs) > limit {
+		recs = recs[:limit]
+	}
+	return recs, nil
+}
+
+// modelKey identifies a model: the pool it analyses and the locale it was
+// analysed in.
+type modelKey struct {
+	pool   PoolKey
+	locale language.Tag
+}
+
+func (s *Similar) model(pool Pool, locale language.Tag) *model {
+	key := modelKey{pool.Key, locale}
+	s.mu.Lock()
+	m, ok := s.models[key]
+	s.mu.Unlock()
+	if ok {
+		return m
+	}
+
+	m = newModel(pool.Courses, locale)
+	s.mu.Lock()
+	if len(s.models) >= modelCacheSize {
+		clear(s.models)
+	}
+	s.models[key] = m
+	s.mu.Unlock()
+	return m
+}
+
+// model holds the analysed candidates and the overview term weights.
+type model struct {
+	locale   language.Tag
+	idf      map[string]float64
+	profiles map[int]profile
+}
+
+// profile is a course reduced to what is compared: its subject names and a
+// unit TF-IDF vector of its overview.
+type profile struct {
+	subjects []string
+	text     map[string]float64
+}
+
+func newModel(courses []graph.CourseView, locale language.Tag) *model {
+	m := &model{locale: locale, idf: map[string]float64{}, profiles: make(map[int]profile, len(courses))}
+
+	terms := make([][]string, len(courses))
+	for i, c := range courses {
+		terms[i] = search.Terms(c.Course.Overview, locale)
+		seen := map[string]bool{}
+		for _, t := range terms[i] {
+			if !seen[t] {
+				seen[t] = true
+				m.idf[t]++
+			}
+		}
+	}
+	for t, df := range m.idf {
+		m.idf[t] = math.Log(1 + float64(len(courses))/df)
+	}
+
+	for i, c := range courses {
+		m.profiles[c.ID] = profile{subjects: subjects(c), text: m.vector(terms[i])}
+	}
+	return m
+}
+
+// profile analyses a course that isn't among the candidates.
+func (m *model) profile(c graph.CourseView) profile {
+	return profile{subjects: subjects(c), text: m.vector(search.Terms(c.Course.Overview, m.locale))}
+}
+
+// vector weights terms by TF-IDF and normalises to unit length. Terms unseen
+// in the candidates can't match any of them, so they are left out.
+func (m *model) vector(terms []string) map[string]float64 {
+	v := map[string]float64{}
+	for _, t := range terms {
+		if idf, ok := m.idf[t]; ok {
+			v[t] += idf
+		}
+	}
+	var norm float64
+	for _, w := range v {
+		norm += w * w
+	}
+	norm = math.Sqrt(norm)
+	for t := range v {
+		v[t] /= norm
+	}
+	return v
+}
+
+func subjects(c graph.CourseView) []string {
+	names := search.Subjects(c)
+	for i, name := range names {
+		names[i] = strings.ToLower(name)
+	}
+	return names
+}
+
+// cosine is the dot product of two unit vectors.
+func cosine(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var dot float64
+	for t, w := range a {
+		dot += w * b[t]
+	}
+	return dot
+}
+
+// jaccard is the share of distinct values the two lists have in common.
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	union := map[string]bool{}
+	for _, v := range a {
+		union[v] = false
+	}
+	var shared int
+	for _, v := range b {
+		if seen, ok := union[v]; ok && !seen {
+			shared++
+		}
+		union[v] = true
+	}
+	return float64(shared) / float64(len(union))
+}
@@ -0,0 +1,100 @@
+package recommend
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+)
+
+func course(id int, name string, brand int, level, delivery []string, overview string) graph.CourseView {
+	return graph.CourseView{Course: models.Course{
+		ID:         id,
+		CourseName: name,
+		BrandID:    brand,
+		Level:      level,
+		Delivery:   delivery,
+		Overview:   overview,
+	}}
+}
+
+func recommendedIDs(recs []Recommendation) []int {
+	var ids []int
+	for _, r := range recs {
+		ids = append(ids, r.Course.ID)
+	}
+	return ids
+}
+
+func TestSimilarRecommend(t *testing.T) {
+	diploma, online := []string{"Diploma"}, []string{"Online"}
+	leadership := course(1, "Diploma of Leadership", 1, diploma, online, "Lead teams and manage people in the workplace.")
+	pool := Pool{Courses: []graph.CourseView{
+		leadership,
+		course(2, "Diploma of Management", 1, diploma, online, "Manage people and lead teams to deliver results."),
+		course(3, "Certificate IV in Leadership", 2, []string{"Certificate IV"}, online, "Lead teams in small businesses."),
+		course(4, "Diploma of Nursing", 3, diploma, []string{"On campus"}, "Care for patients in hospitals."),
+		course(5, "Certificate III in Cookery", 4, nil, nil, "Cook meals in commercial kitchens."),
+	}}
+
+	tests := []struct {
+		name    string
+		course  graph.CourseView
+		weights Weights
+		limit   int
+		want    []int
+	}{
+		{name: "closest first", course: leadership, weights: DefaultWeights, limit: 10, want: []int{2, 4, 3}},
+		{name: "limit", course: leadership, weights: DefaultWeights, limit: 1, want: []int{2}},
+		{name: "zero limit", course: leadership, weights: DefaultWeights, limit: 0},
+		{name: "zero weights", course: leadership, limit: 10},
+		{name: "text only", course: leadership, weights: Weights{Text: 1}, limit: 10, want: []int{2, 3}},
+		{
+			name:    "course outside pool",
+			course:  course(9, "Graduate Certificate in Leadership", 0, nil, nil, "Lead teams in large organisations."),
+			weights: Weights{Text: 1},
+			limit:   10,
+			want:    []int{1, 3, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs, err := NewSimilar(tt.weights).Recommend(context.Background(), tt.course, pool, tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := recommendedIDs(recs); !slices.Equal(got, tt.want) {
+				t.Errorf("Recommend() = %v, want %v", got, tt.want)
+			}
+			for _, r := range recs {
+				if r.Score < minScore || r.Score > 1 {
+					t.Errorf("course %d scored %v, want between %v and 1", r.Course.ID, r.Score, minScore)
+				}
+			}
+		})
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want float64
+	}{
+		{name: "empty", a: nil, b: []string{"x"}, want: 0},
+		{name: "same", a: []string{"x", "y"}, b: []string{"y", "x"}, want: 1},
+		{name: "half", a: []string{"x"}, b: []string{"x", "y"}, want: 0.5},
+		{name: "disjoint", a: []string{"x"}, b: []string{"y"}, want: 0},
+		{name: "duplicates", a: []string{"x", "x"}, b: []string{"x", "x", "y"}, want: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jaccard(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("jaccard(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
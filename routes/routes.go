@@ -8,19 +8,53 @@ import (
 )
 
 // SetupRoutes defines all application routes.
-func SetupRoutes(router *gin.Engine) {
+func SetupRoutes(router *gin.Engine, h *handlers.Handler) {
+	// Health probes
+	h.RegisterReadinessChecks()
+	router.GET("/healthz", h.HealthzHandler)
+	router.GET("/readyz", h.ReadyzHandler)
+
+	// The visitor's country, for geo-targeted courses, and the embedding
+	// partner, whose features gate parts of the UI
+	router.Use(h.Locate, h.IdentifyPartner)
+
+	// Cacheable responses that don't depend on learner cookies, registered
+	// before the middleware below so they never carry its cookies
+	router.GET("/autocomplete", h.AutocompleteHandler)
+	router.GET("/sdk/:file", h.SDKHandler)
+	router.GET("/sdk/config/:partnerKey", h.SDKConfigHandler)
+
+	// Saved and recently viewed courses for the templates, and CSRF checks
+	// for every state-changing route below
+	router.Use(h.LoadLists, h.CSRF)
+
 	// Public routes
-	router.GET("/", handlers.HomeHandler)
-	router.GET("/courses/:id", handlers.CourseHandler)
-	router.GET("/courses/:id/curriculum", handlers.CurriculumHandler)
-	router.GET("/courses/:id/eligibility", handlers.EligibilityHandler)
-	router.GET("/courses/:id/career", handlers.CareerHandler)
-	router.GET("/courses/:id/recognition", handlers.RecognitionHandler)
-	router.GET("/courses/:id/info", handlers.InfoHandler)
-	router.GET("/close-modal", handlers.CloseModal)
-	router.POST("/auth/callback", handlers.AuthCallback)
-
-	// Protected routes group (example)
+	router.GET("/", h.HomeHandler)
+	router.GET("/courses", h.CoursesHandler)
+	router.GET("/search", h.SearchHandler)
+	router.GET("/courses/:id", h.CourseHandler)
+	router.GET("/courses/:id/curriculum", h.CurriculumHandler)
+	router.GET("/courses/:id/units", h.UnitHandler)
+	router.GET("/courses/:id/eligibility", h.EligibilityHandler)
+	router.GET("/courses/:id/eligibility/check", h.EligibilityCheckHandler)
+	router.GET("/courses/:id/career", h.CareerHandler)
+	router.GET("/courses/:id/recognition", h.RecognitionHandler)
+	router.GET("/courses/:id/info", h.InfoHandler)
+	router.GET("/courses/:id/intakes.ics", h.IntakesICSHandler)
+	router.GET("/courses/:id/similar", h.SimilarHandler)
+	router.GET("/courses/:id/enquiry", h.EnquiryFormHandler)
+	router.POST("/courses/:id/enquiry", h.EnquiryHandler)
+	router.GET("/courses/:id/fees", h.FeesHandler)
+	router.GET("/compare", h.CompareHandler)
+	router.GET("/compare/tray", h.CompareTrayHandler)
+	router.GET("/saved", h.SavedHandler)
+	router.POST("/saved/:id", h.SaveHandler)
+	router.GET("/close-modal", h.CloseModal)
+	router.POST("/auth/callback", h.AuthCallback)
+	router.POST("/auth/signout", h.SignOut)
+
+	// Protected routes group (example). Bearer tokens authenticate these,
+	// so they are exempt from CSRF checks.
 	protected := router.Group("/api")
 	protected.Use(middleware.AuthMiddleware())
 	{
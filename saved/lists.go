@@ -0,0 +1,99 @@
+// Package saved keeps a learner's shortlist of saved courses and the courses
+// they recently viewed.
+package saved
+
+import (
+	"context"
+	"slices"
+)
+
+const (
+	// MaxSaved bounds the shortlist; saving beyond it drops the oldest.
+	MaxSaved = 50
+	// MaxRecent is how many recently viewed courses are remembered.
+	MaxRecent = 10
+)
+
+// Lists are a learner's saved and recently viewed course IDs, newest first.
+type Lists struct {
+	Saved  []int `json:"saved,omitempty"`
+	Recent []int `json:"recent,omitempty"`
+}
+
+// Empty reports whether both lists are empty.
+func (l Lists) Empty() bool {
+	return len(l.Saved) == 0 && len(l.Recent) == 0
+}
+
+// IsSaved reports whether the course is on the shortlist.
+func (l Lists) IsSaved(id int) bool {
+	return slices.Contains(l.Saved, id)
+}
+
+// Toggle saves the course, or unsaves it if it was saved, and reports
+// whether it is now saved.
+func (l Lists) Toggle(id int) (Lists, bool) {
+	if i := slices.Index(l.Saved, id); i >= 0 {
+		l.Saved = slices.Delete(slices.Clone(l.Saved), i, i+1)
+		return l, false
+	}
+	l.Saved = prepend(l.Saved, id, MaxSaved)
+	return l, true
+}
+
+// Viewed records the course as the most recently viewed.
+func (l Lists) Viewed(id int) Lists {
+	l.Recent = prepend(l.Recent, id, MaxRecent)
+	return l
+}
+
+// Merge combines lists kept in two places, such as a signed-in user's lists
+// and those built up anonymously before signing in. The other lists' entries
+// come first, since they are the latest activity.
+func (l Lists) Merge(other Lists) Lists {
+	return Lists{
+		Saved:  union(other.Saved, l.Saved, MaxSaved),
+		Recent: union(other.Recent, l.Recent, MaxRecent),
+	}
+}
+
+// Forget removes the courses from both lists, e.g. once they are no longer in
+// the catalogue.
+func (l Lists) Forget(ids []int) Lists {
+	gone := func(id int) bool { return slices.Contains(ids, id) }
+	l.Saved = slices.DeleteFunc(slices.Clone(l.Saved), gone)
+	l.Recent = slices.DeleteFunc(slices.Clone(l.Recent), gone)
+	return l
+}
+
+func prepend(ids []int, id, limit int) []int {
+	return union([]int{id}, ids, limit)
+}
+
+// union returns the distinct IDs of a then b, up to limit.
+func union(a, b []int, limit int) []int {
+	var out []int
+	for _, id := range slices.Concat(a, b) {
+		if len(out) == limit {
+			break
+		}
+		if !slices.Contains(out, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+type contextKey struct{}
+
+// WithLists stores the request's lists in ctx for templates.
+func WithLists(ctx context.Context, l Lists) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the request's lists, or empty lists if none were
+// loaded.
+func FromContext(ctx context.Context) Lists {
+	l, _ := ctx.Value(contextKey{}).(Lists)
+	return l
+}
@@ -0,0 +1,102 @@
+package saved
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cookie prefixes the names of the signed cookies holding an anonymous
+// learner's lists, one per tenant.
+const Cookie = "mf_lists_"
+
+// cookieAge is how long anonymous lists are kept.
+const cookieAge = 90 * 24 * time.Hour
+
+// cookieName is the tenant's lists cookie. Course IDs belong to the tenant's
+// backend, so each tenant keeps its own lists. The ID is encoded since
+// tenant IDs may hold characters cookie names can't.
+func cookieName(tenant string) string {
+	return Cookie + base64.RawURLEncoding.EncodeToString([]byte(tenant))
+}
+
+// Service reads and writes the lists for a request: from the store for a
+// signed-in user, and from a signed cookie otherwise. Lists are kept per
+// tenant.
+type Service struct {
+	store    Store
+	sessions *auth.Sessions
+	cookies  auth.Cookies
+}
+
+// NewService returns a Service keeping signed-in users' lists in store.
+func NewService(store Store, sessions *auth.Sessions, cookies auth.Cookies) *Service {
+	return &Service{store: store, sessions: sessions, cookies: cookies}
+}
+
+// Load returns the request's lists for tenant. A missing or tampered cookie
+// means empty lists.
+func (s *Service) Load(c *gin.Context, tenant string) (Lists, error) {
+	if user, ok := s.sessions.User(c); ok {
+		return s.store.Load(c.Request.Context(), tenant, user)
+	}
+	var lists Lists
+	if value, ok := s.cookies.Get(c, cookieName(tenant)); ok {
+		_ = json.Unmarshal(value, &lists)
+	}
+	return lists, nil
+}
+
+// Save replaces the request's lists for tenant.
+func (s *Service) Save(c *gin.Context, tenant string, lists Lists) error {
+	if user, ok := s.sessions.User(c); ok {
+		return s.store.Save(c.Request.Context(), tenant, user, lists)
+	}
+	value, err := json.Marshal(lists)
+	if err != nil {
+		return err
+	}
+	s.cookies.Set(c, cookieName(tenant), value, cookieAge)
+	return nil
+}
+
+// SignIn starts a session for user and merges the lists built up
+// anonymously, under every tenant, into theirs, then drops the cookies.
+func (s *Service) SignIn(c *gin.Context, user string) error {
+	ctx := c.Request.Context()
+	var merged []string
+	for _, cookie := range c.Request.Cookies() {
+		encoded, ok := strings.CutPrefix(cookie.Name, Cookie)
+		if !ok {
+			continue
+		}
+		tenant, err := base64.RawURLEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		var anonymous Lists
+		if value, ok := s.cookies.Get(c, cookie.Name); ok {
+			_ = json.Unmarshal(value, &anonymous)
+		}
+		if !anonymous.Empty() {
+			lists, err := s.store.Load(ctx, string(tenant), user)
+			if err != nil {
+				return err
+			}
+			if err := s.store.Save(ctx, string(tenant), user, lists.Merge(anonymous)); err != nil {
+				return err
+			}
+		}
+		merged = append(merged, cookie.Name)
+	}
+	s.sessions.Start(c, user)
+	for _, name := range merged {
+		s.cookies.Clear(c, name)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package saved
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store keeps signed-in users' lists, per tenant.
+type Store interface {
+	Load(ctx context.Context, tenant, user string) (Lists, error)
+	Save(ctx context.Context, tenant, user string, lists Lists) error
+}
+
+// FileStore keeps every user's lists in one JSON file, keyed by tenant ID
+// and then user. It is read once and
+// rewritten on every change, which suits the modest number of signed-in
+// learners a deployment sees; a database can replace it behind Store.
+type FileStore struct {
+	path string
+
+	mu     sync.Mutex
+	loaded bool
+	users  map[string]map[string]Lists // tenant → user → lists
+}
+
+// NewFileStore returns a store backed by path. The file and its directory are
+// created on the first save.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load returns the user's lists for tenant, or empty lists for a new user.
+func (s *FileStore) Load(ctx context.Context, tenant, user string) (Lists, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return Lists{}, err
+	}
+	return s.users[tenant][user], nil
+}
+
+// Save replaces the user's lists for tenant.
+func (s *FileStore) Save(ctx context.Context, tenant, user string, lists Lists) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	previous, existed := s.users[tenant][user]
+	s.put(tenant, user, lists)
+	if err := s.write(); err != nil {
+		// Keep memory in line with the file
+		if existed {
+			s.put(tenant, user, previous)
+		} else {
+			s.put(tenant, user, Lists{})
+		}
+		return err
+	}
+	return nil
+}
+
+// put sets the user's lists, dropping empty ones so the file only holds
+// users with something saved.
+func (s *FileStore) put(tenant, user string, lists Lists) {
+	if lists.Empty() {
+		delete(s.users[tenant], user)
+		if len(s.users[tenant]) == 0 {
+			delete(s.users, tenant)
+		}
+		return
+	}
+	if s.users[tenant] == nil {
+		s.users[tenant] = map[string]Lists{}
+	}
+	s.users[tenant][user] = lists
+}
+
+func (s *FileStore) load() error {
+	if s.loaded {
+		return nil
+	}
+	s.users = map[string]map[string]Lists{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.loaded = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &s.users); err != nil {
+		return fmt.Errorf("decoding %s: %w", s.path, err)
+	}
+	s.loaded = true
+	return nil
+}
+
+// write replaces the file atomically, so a crash mid-write can't lose every
+// user's lists.
+func (s *FileStore) write() error {
+	data, err := json.Marshal(s.users)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
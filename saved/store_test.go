@@ -0,0 +1,63 @@
+package saved
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFileStoreKeepsTenantsApart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "lists.json")
+	store := NewFileStore(path)
+
+	if err := store.Save(ctx, "acme", "ada", Lists{Saved: []int{1, 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(ctx, "globex", "ada", Lists{Saved: []int{3}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(ctx, "globex", "ada", Lists{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A fresh store reads what the first one wrote
+	reread := NewFileStore(path)
+	tests := []struct {
+		tenant, user string
+		want         []int
+	}{
+		{"acme", "ada", []int{1, 2}},
+		{"globex", "ada", nil},
+		{"acme", "grace", nil},
+		{"initech", "ada", nil},
+	}
+	for _, tt := range tests {
+		got, err := reread.Load(ctx, tt.tenant, tt.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got.Saved, tt.want) {
+			t.Errorf("Load(%q, %q).Saved = %v, want %v", tt.tenant, tt.user, got.Saved, tt.want)
+		}
+	}
+	if _, ok := reread.users["globex"]; ok {
+		t.Errorf("emptied tenant still in the file: %v", reread.users)
+	}
+}
+
+func TestListsForget(t *testing.T) {
+	lists := Lists{Saved: []int{1, 2, 3}, Recent: []int{3, 4}}
+	got := lists.Forget([]int{3, 5})
+
+	if want := []int{1, 2}; !slices.Equal(got.Saved, want) {
+		t.Errorf("Saved = %v, want %v", got.Saved, want)
+	}
+	if want := []int{4}; !slices.Equal(got.Recent, want) {
+		t.Errorf("Recent = %v, want %v", got.Recent, want)
+	}
+	if want := []int{1, 2, 3}; !slices.Equal(lists.Saved, want) {
+		t.Errorf("Forget modified its input: %v", lists.Saved)
+	}
+}
@@ -0,0 +1,216 @@
+// Package sdk describes the published versions of the embeddable JavaScript
+// SDK and computes the Subresource Integrity hashes partners pin against.
+package sdk
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/assets"
+)
+
+// Version is one published SDK release line. Its URL is an alias for the
+// line's newest release, so it moves whenever a release is added.
+type Version struct {
+	Name       string    // e.g. "v2", served at /sdk/v2.js
+	Releases   []string  // release numbers, oldest first
+	Deprecated time.Time // zero unless the version is deprecated
+	Sunset     time.Time // zero unless a removal date has been announced
+	Successor  string    // version partners should migrate to
+}
+
+// Release is one SDK build. Its file never changes once published: a change
+// to the SDK adds a release (and a file) to its line instead.
+type Release struct {
+	Number  string  // e.g. "2.1.0", served at /sdk/v2.1.0.js
+	Version Version // the release line
+}
+
+// LatestAlias is the version name that always points at Latest.
+const LatestAlias = "latest"
+
+// Latest is the version served at /sdk/latest.js.
+const Latest = "v2"
+
+// versions lists every SDK we serve, oldest first.
+var versions = []Version{
+	{
+		Name:       "v1",
+		Releases:   []string{"1.0.0"},
+		Deprecated: time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC),
+		Successor:  "v2",
+	},
+	{
+		Name:     "v2",
+		Releases: []string{"2.0.0", "2.1.0"},
+	},
+}
+
+// Versions returns every published version, oldest first.
+func Versions() []Version {
+	return versions
+}
+
+// Lookup resolves a version name, including the "latest" alias.
+func Lookup(name string) (Version, bool) {
+	if name == LatestAlias {
+		name = Latest
+	}
+	for _, v := range versions {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Version{}, false
+}
+
+// LookupRelease resolves a release name such as "v2.1.0".
+func LookupRelease(name string) (Release, bool) {
+	number, ok := strings.CutPrefix(name, "v")
+	if !ok {
+		return Release{}, false
+	}
+	for _, v := range versions {
+		for _, r := range v.Releases {
+			if r == number {
+				return Release{Number: r, Version: v}, true
+			}
+		}
+	}
+	return Release{}, false
+}
+
+// URL is the public path of the version's alias.
+func (v Version) URL() string {
+	return "/sdk/" + v.Name + ".js"
+}
+
+// Current returns the version's newest release.
+func (v Version) Current() Release {
+	return Release{Number: v.Releases[len(v.Releases)-1], Version: v}
+}
+
+// Name is the release's name, e.g. "v2.1.0".
+func (r Release) Name() string {
+	return "v" + r.Number
+}
+
+// URL is the immutable public path for the release.
+func (r Release) URL() string {
+	return "/sdk/" + r.Name() + ".js"
+}
+
+// File is the release's path inside the assets file system.
+func (r Release) File() string {
+	return "sdk/releases/micro-frontend-sdk-" + r.Number + ".js"
+}
+
+// Source returns the script contents along with its SRI hash and ETag.
+func (r Release) Source() (Script, error) {
+	return load(r.File())
+}
+
+// Script is a loaded SDK file.
+type Script struct {
+	Body      []byte
+	Integrity string // sha384-<base64>, for the integrity attribute
+	ETag      string
+}
+
+var (
+	scriptsMu sync.Mutex
+	scripts   = map[string]Script{}
+)
+
+// load reads and hashes a script once; releases never change, and the
+// assets are embedded in release builds.
+func load(file string) (Script, error) {
+	scriptsMu.Lock()
+	defer scriptsMu.Unlock()
+
+	if s, ok := scripts[file]; ok {
+		return s, nil
+	}
+
+	body, err := fs.ReadFile(assets.FS(), file)
+	if err != nil {
+		return Script{}, fmt.Errorf("reading SDK %s: %w", file, err)
+	}
+	sri := sha512.Sum384(body)
+	tag := sha256.Sum256(body)
+
+	s := Script{
+		Body:      body,
+		Integrity: "sha384-" + base64.StdEncoding.EncodeToString(sri[:]),
+		ETag:      `"` + hex.EncodeToString(tag[:8]) + `"`,
+	}
+	scripts[file] = s
+	return s, nil
+}
+
+// ReleaseEntry describes one release in the JSON manifest.
+type ReleaseEntry struct {
+	Version   string `json:"version"`
+	URL       string `json:"url"`
+	Integrity string `json:"integrity"`
+}
+
+// ManifestEntry describes one version in the JSON manifest. Its URL is the
+// moving alias; pin one of its releases instead.
+type ManifestEntry struct {
+	Version    string         `json:"version"`
+	URL        string         `json:"url"`
+	Current    ReleaseEntry   `json:"current"`
+	Releases   []ReleaseEntry `json:"releases"`
+	Deprecated bool           `json:"deprecated"`
+	Sunset     string         `json:"sunset,omitempty"`
+	Successor  string         `json:"successor,omitempty"`
+}
+
+// Manifest lists every version with its releases and their integrity hashes.
+type Manifest struct {
+	Latest   string          `json:"latest"`
+	Versions []ManifestEntry `json:"versions"`
+}
+
+// BuildManifest hashes every release and returns the manifest.
+func BuildManifest() (Manifest, error) {
+	m := Manifest{Latest: Latest}
+	for _, v := range versions {
+		entry := ManifestEntry{
+			Version:    v.Name,
+			URL:        v.URL(),
+			Deprecated: !v.Deprecated.IsZero(),
+		}
+		for _, number := range v.Releases {
+			r := Release{Number: number, Version: v}
+			s, err := r.Source()
+			if err != nil {
+				return Manifest{}, err
+			}
+			entry.Releases = append(entry.Releases, ReleaseEntry{
+				Version:   r.Name(),
+				URL:       r.URL(),
+				Integrity: s.Integrity,
+			})
+		}
+		entry.Current = entry.Releases[len(entry.Releases)-1]
+		if !v.Sunset.IsZero() {
+			entry.Sunset = v.Sunset.Format(time.RFC3339)
+		}
+		if v.Successor != "" {
+			if succ, ok := Lookup(v.Successor); ok {
+				entry.Successor = succ.URL()
+			}
+		}
+		m.Versions = append(m.Versions, entry)
+	}
+	return m, nil
+}
@@ -0,0 +1,97 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{name: "v1", want: "v1", wantOK: true},
+		{name: "v2", want: "v2", wantOK: true},
+		{name: LatestAlias, want: Latest, wantOK: true},
+		{name: "v3"},
+		{name: "2"},
+		{name: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := Lookup(tt.name)
+			if ok != tt.wantOK || v.Name != tt.want {
+				t.Errorf("Lookup(%q) = %q, %v, want %q, %v", tt.name, v.Name, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLookupRelease(t *testing.T) {
+	tests := []struct {
+		name        string
+		wantVersion string
+		wantURL     string
+		wantOK      bool
+	}{
+		{name: "v1.0.0", wantVersion: "v1", wantURL: "/sdk/v1.0.0.js", wantOK: true},
+		{name: "v2.0.0", wantVersion: "v2", wantURL: "/sdk/v2.0.0.js", wantOK: true},
+		{name: "v2.1.0", wantVersion: "v2", wantURL: "/sdk/v2.1.0.js", wantOK: true},
+		{name: "2.1.0"},
+		{name: "v2.9.0"},
+		{name: "v2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, ok := LookupRelease(tt.name)
+			if ok != tt.wantOK {
+				t.Fatalf("LookupRelease(%q) ok = %v, want %v", tt.name, ok, tt.wantOK)
+			}
+			if ok && (r.Version.Name != tt.wantVersion || r.URL() != tt.wantURL) {
+				t.Errorf("LookupRelease(%q) = %s %s, want %s %s", tt.name, r.Version.Name, r.URL(), tt.wantVersion, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestBuildManifest(t *testing.T) {
+	m, err := BuildManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Latest != Latest {
+		t.Errorf("Latest = %q, want %q", m.Latest, Latest)
+	}
+
+	tests := []struct {
+		version    string
+		current    string
+		releases   int
+		deprecated bool
+		successor  string
+	}{
+		{version: "v1", current: "v1.0.0", releases: 1, deprecated: true, successor: "/sdk/v2.js"},
+		{version: "v2", current: "v2.1.0", releases: 2},
+	}
+	if len(m.Versions) != len(tests) {
+		t.Fatalf("manifest has %d versions, want %d", len(m.Versions), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			e := m.Versions[i]
+			if e.Version != tt.version || e.Current.Version != tt.current || len(e.Releases) != tt.releases {
+				t.Errorf("entry = %s current %s with %d releases, want %s current %s with %d",
+					e.Version, e.Current.Version, len(e.Releases), tt.version, tt.current, tt.releases)
+			}
+			if e.Deprecated != tt.deprecated || e.Successor != tt.successor {
+				t.Errorf("deprecated, successor = %v, %q, want %v, %q", e.Deprecated, e.Successor, tt.deprecated, tt.successor)
+			}
+			for _, r := range e.Releases {
+				if !strings.HasPrefix(r.Integrity, "sha384-") {
+					t.Errorf("release %s integrity = %q", r.Version, r.Integrity)
+				}
+			}
+		})
+	}
+}
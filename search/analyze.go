@@ -0,0 +1,160 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/text/language"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var stripTags = bluemonday.StrictPolicy()
+
+// plainText reduces backend HTML to its text.
+func plainText(s string) string {
+	return html.UnescapeString(stripTags.Sanitize(s))
+}
+
+// fold lower-cases s and removes diacritics, so "Gestión" and "gestion"
+// compare equal.
+func fold(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, strings.ToLower(s))
+	if err != nil {
+		return strings.ToLower(s)
+	}
+	return folded
+}
+
+// words splits s into folded words.
+func words(s string) []string {
+	return strings.FieldsFunc(fold(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// stopwords are skipped when indexing and searching.
+var stopwords = map[language.Base]map[string]bool{
+	base("en"): set("a", "an", "and", "are", "as", "at", "be", "by", "for", "from", "in", "into", "is", "it", "of", "on", "or", "the", "to", "with", "you", "your"),
+	base("es"): set("a", "al", "con", "de", "del", "el", "en", "es", "la", "las", "los", "o", "para", "por", "que", "se", "su", "sus", "un", "una", "y"),
+}
+
+func base(s string) language.Base {
+	b, _ := language.MustParse(s).Base()
+	return b
+}
+
+func set(words ...string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}
+
+// Terms returns the terms search would index for text in locale: markup
+// stripped, words folded, stopwords dropped and the rest stemmed. It lets
+// other packages compare course text the way search does.
+func Terms(text string, locale language.Tag) []string {
+	terms, _ := newAnalyzer(locale).terms(plainText(text))
+	return terms
+}
+
+// analyzer turns text into index terms for one language.
+type analyzer struct {
+	lang language.Base
+}
+
+func newAnalyzer(locale language.Tag) analyzer {
+	b, _ := locale.Base()
+	return analyzer{lang: b}
+}
+
+// terms returns the stemmed terms of s alongside the folded words they came
+// from, which are kept for prefix matching.
+func (a analyzer) terms(s string) (terms, raw []string) {
+	for _, w := range words(s) {
+		if stopwords[a.lang][w] {
+			continue
+		}
+		terms = append(terms, a.stem(w))
+		raw = append(raw, w)
+	}
+	return terms, raw
+}
+
+// stem is a light suffix-stripping stemmer. It only needs to conflate common
+// inflections (plurals, -ing, -ment...) of the same word, and the same rules
+// are applied to the index and the query, so it errs on the side of leaving
+// words alone.
+func (a analyzer) stem(w string) string {
+	if len(w) <= 3 || !isAlpha(w) {
+		return w
+	}
+	switch a.lang {
+	case base("es"):
+		return stemSpanish(w)
+	default:
+		return stemEnglish(w)
+	}
+}
+
+func isAlpha(w string) bool {
+	for _, r := range w {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func stemEnglish(w string) string {
+	switch {
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		w = w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "sses"):
+		w = w[:len(w)-2]
+	case strings.HasSuffix(w, "xes") || strings.HasSuffix(w, "ches") || strings.HasSuffix(w, "shes"):
+		w = w[:len(w)-2]
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us") && !strings.HasSuffix(w, "is"):
+		w = w[:len(w)-1]
+	}
+
+	for _, suffix := range []string{"ational", "ation", "ment", "ness", "ing", "ed", "ly"} {
+		stem, ok := strings.CutSuffix(w, suffix)
+		if !ok || len(stem) < 3 {
+			continue
+		}
+		switch suffix {
+		case "ational", "ation":
+			stem += "ate"
+		case "ing", "ed":
+			// running -> run, planned -> plan
+			if n := len(stem); stem[n-1] == stem[n-2] && !strings.ContainsAny(stem[n-1:], "lsz") {
+				stem = stem[:n-1]
+			}
+		}
+		return stem
+	}
+	return w
+}
+
+func stemSpanish(w string) string {
+	if stem, ok := strings.CutSuffix(w, "mente"); ok && len(stem) >= 3 {
+		return stem
+	}
+	switch {
+	case strings.HasSuffix(w, "es") && len(w) > 4 && !strings.ContainsAny(w[len(w)-3:len(w)-2], "aeiou"):
+		w = w[:len(w)-2]
+	case strings.HasSuffix(w, "s"):
+		w = w[:len(w)-1]
+	}
+	if len(w) > 4 && strings.ContainsAny(w[len(w)-1:], "aeo") {
+		w = w[:len(w)-1]
+	}
+	return w
+}
@@ -0,0 +1,173 @@
+// Package search is an in-memory full-text index over the cached course
+// catalogue.
+package search
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+
+	"golang.org/x/text/language"
+)
+
+// field is an indexed part of a course and how much a match in it counts.
+type field struct {
+	boost float64
+	text  func(graph.CourseView) string
+}
+
+var fields = []field{
+	{boost: 8, text: func(c graph.CourseView) string { return c.CourseCode }},
+	{boost: 5, text: func(c graph.CourseView) string { return c.CourseName }},
+	{boost: 3, text: subjectNames},
+	{boost: 2, text: func(c graph.CourseView) string { return plainText(c.Course.WhatYoullLearn) }},
+	{boost: 1, text: func(c graph.CourseView) string { return plainText(c.Course.Overview) }},
+}
+
+// prefixWeight is how much a prefix match counts against an exact one.
+const prefixWeight = 0.5
+
+// Index is an inverted index over one course list.
+type Index struct {
+	analyzer analyzer
+	courses  []graph.CourseView
+	// postings maps a term to the boosted frequency per course position
+	postings map[string]map[int]float64
+	// words maps each folded word seen to its term, and wordList holds them
+	// sorted so prefixes can be found by binary search
+	words    map[string]string
+	wordList []string
+
+	// entries are the texts offered as autocomplete suggestions, and
+	// suggestions caches their matches per query prefix
+	entries     []entry
+	mu          sync.Mutex
+	suggestions map[string][]Suggestion
+}
+
+// Build indexes courses, analysing text with the rules for locale.
+func Build(courses []graph.CourseView, locale language.Tag) *Index {
+	ix := &Index{
+		analyzer:    newAnalyzer(locale),
+		courses:     courses,
+		postings:    map[string]map[int]float64{},
+		words:       map[string]string{},
+		entries:     suggestionEntries(courses),
+		suggestions: map[string][]Suggestion{},
+	}
+	for i, course := range courses {
+		for _, f := range fields {
+			terms, raw := ix.analyzer.terms(f.text(course))
+			for j, term := range terms {
+				if ix.postings[term] == nil {
+					ix.postings[term] = map[int]float64{}
+				}
+				ix.postings[term][i] += f.boost
+				ix.words[raw[j]] = term
+			}
+		}
+	}
+	for w := range ix.words {
+		ix.wordList = append(ix.wordList, w)
+	}
+	slices.Sort(ix.wordList)
+	return ix
+}
+
+// Hit is a course matching a search and its score.
+type Hit struct {
+	Course graph.CourseView
+	Score  float64
+}
+
+// Search returns the courses matching every word of query, best first. A
+// word matches a course when the course contains it after stemming, or
+// contains a word it is the prefix of (so partial words typed so far match,
+// at a lower score).
+func (ix *Index) Search(query string) []Hit {
+	terms, raw := ix.analyzer.terms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var scores map[int]float64
+	for i, term := range terms {
+		matches := ix.match(term, raw[i])
+		if scores == nil {
+			scores = matches
+			continue
+		}
+		for doc, score := range scores {
+			if m, ok := matches[doc]; ok {
+				scores[doc] = score + m
+			} else {
+				delete(scores, doc)
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for doc, score := range scores {
+		hits = append(hits, Hit{Course: ix.courses[doc], Score: score})
+	}
+	slices.SortFunc(hits, func(a, b Hit) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Course.CourseName, b.Course.CourseName)
+	})
+	return hits
+}
+
+// match scores each course for one query word: exact term matches at full
+// weight, and terms of words starting with it at prefixWeight.
+func (ix *Index) match(term, word string) map[int]float64 {
+	scores := map[int]float64{}
+	add := func(t string, weight float64) {
+		postings := ix.postings[t]
+		if len(postings) == 0 {
+			return
+		}
+		idf := math.Log(1 + float64(len(ix.courses))/float64(len(postings)))
+		for doc, tf := range postings {
+			scores[doc] = max(scores[doc], weight*idf*tf)
+		}
+	}
+
+	add(term, 1)
+	if len(word) >= 2 {
+		start, _ := slices.BinarySearch(ix.wordList, word)
+		for _, w := range ix.wordList[start:] {
+			if !strings.HasPrefix(w, word) {
+				break
+			}
+			if t := ix.words[w]; t != term {
+				add(t, prefixWeight)
+			}
+		}
+	}
+	return scores
+}
+
+// Subjects returns the names of the course's modules and units.
+func Subjects(c graph.CourseView) []string {
+	var names []string
+	for _, m := range c.Modules {
+		if m.Name != "" {
+			names = append(names, m.Name)
+		}
+		for _, u := range m.Units {
+			names = append(names, u.Name)
+		}
+	}
+	return names
+}
+
+// subjectNames returns the module and unit names, one per line.
+func subjectNames(c graph.CourseView) string {
+	return strings.Join(Subjects(c), "\n")
+}
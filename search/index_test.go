@@ -0,0 +1,78 @@
+package search
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+
+	"golang.org/x/text/language"
+)
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		locale language.Tag
+		want   []string
+	}{
+		{name: "plurals", text: "courses studies classes boxes", locale: language.English, want: []string{"course", "study", "class", "box"}},
+		{name: "inflections", text: "running planned management quickly relational", locale: language.English, want: []string{"run", "plan", "manage", "quick", "relate"}},
+		{name: "words left alone", text: "status analysis sell bsb50420", locale: language.English, want: []string{"status", "analysis", "sell", "bsb50420"}},
+		{name: "stopwords", text: "The Art of the Deal", locale: language.English, want: []string{"art", "deal"}},
+		{name: "markup and entities", text: "<p>Team&nbsp;<b>leaders</b></p>", locale: language.English, want: []string{"team", "leader"}},
+		{name: "case and diacritics", text: "Gestión CAFÉ", locale: language.English, want: []string{"gestion", "cafe"}},
+		{name: "spanish", text: "Los cursos y el curso profesionales", locale: language.Spanish, want: []string{"curs", "curs", "profesional"}},
+		{name: "spanish adverb", text: "rápidamente", locale: language.Spanish, want: []string{"rapida"}},
+		{name: "regional locale", text: "Cursos", locale: language.MustParse("es-MX"), want: []string{"curs"}},
+		{name: "empty", text: " - ", locale: language.English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Terms(tt.text, tt.locale); !slices.Equal(got, tt.want) {
+				t.Errorf("Terms(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	leadership := course(1, "BSB50420", "Diploma of Leadership and Management")
+	project := course(2, "BSB40920", "Certificate IV in Project Management")
+	project.Course.Overview = "<p>Build leadership skills on the job.</p>"
+	business := course(3, "BSB30120", "Certificate III in Business")
+	business.Modules = []models.Module{{Name: "Core", Units: []models.Unit{{Name: "Lead a team"}}}}
+	gestion := course(4, "", "Gestión de proyectos")
+
+	ix := Build([]graph.CourseView{leadership, project, business, gestion}, language.English)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{name: "name above overview", query: "leadership", want: []int{1, 2}},
+		{name: "code", query: "bsb50420", want: []int{1}},
+		{name: "code prefix", query: "bsb", want: []int{3, 2, 1}},
+		{name: "stemmed", query: "certificates", want: []int{3, 2}},
+		{name: "prefix of a longer word, ties by name", query: "manag", want: []int{2, 1}},
+		{name: "every word must match", query: "leadership management", want: []int{1, 2}},
+		{name: "word matching nothing", query: "leadership zebra"},
+		{name: "subject", query: "team", want: []int{3}},
+		{name: "diacritics folded", query: "GESTION", want: []int{4}},
+		{name: "stopwords only", query: "the of and"},
+		{name: "single letter is no prefix", query: "l"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, hit := range ix.Search(tt.query) {
+				got = append(got, hit.Course.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
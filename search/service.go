@@ -0,0 +1,117 @@
+package search
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+
+	"golang.org/x/text/language"
+)
+
+type indexKey struct {
+	client *graph.Client
+	tag    string
+	locale language.Tag
+}
+
+// maxIndexes bounds the indexes kept. Tags come from request params, so
+// the least recently used index makes way for a new one when it is full.
+const maxIndexes = 32
+
+type indexEntry struct {
+	index  *Index
+	usedAt time.Time
+}
+
+// Service keeps a search index per backend client, tag and locale.
+type Service struct {
+	mu      sync.Mutex
+	indexes map[indexKey]indexEntry
+}
+
+// NewService returns an empty Service. Indexes are built on first search and
+// rebuilt whenever a watched client refreshes its cache.
+func NewService() *Service {
+	return &Service{indexes: map[indexKey]indexEntry{}}
+}
+
+// Watch rebuilds the client's indexes as its cache refresher fetches new
+// course lists, so searches don't pay for the rebuild.
+func (s *Service) Watch(client *graph.Client) {
+	client.OnRefresh(func(tag string, locale language.Tag, courses []graph.CourseView) {
+		s.store(indexKey{client, tag, locale}, Build(courses, locale))
+	})
+}
+
+// Search runs query against the client's courses for tag in locale.
+func (s *Service) Search(ctx context.Context, client *graph.Client, tag string, locale language.Tag, query string) ([]Hit, error) {
+	ix, err := s.index(ctx, client, tag, locale)
+	if err != nil {
+		return nil, err
+	}
+	return ix.Search(query), nil
+}
+
+// Suggest returns up to limit autocomplete suggestions for query from the
+// client's courses for tag in locale, leaving out courses keep rejects.
+func (s *Service) Suggest(ctx context.Context, client *graph.Client, tag string, locale language.Tag, query string, limit int, keep func(courseID int) bool) ([]Suggestion, error) {
+	ix, err := s.index(ctx, client, tag, locale)
+	if err != nil {
+		return nil, err
+	}
+	return ix.Suggest(query, limit, keep), nil
+}
+
+// index returns the current index for the client's list, building it if the
+// list is new.
+func (s *Service) index(ctx context.Context, client *graph.Client, tag string, locale language.Tag) (*Index, error) {
+	courses, err := client.GetCourses(ctx, tag, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	key := indexKey{client, tag, locale}
+	ix := s.load(key)
+	// Rebuild if the cached list has changed since the index was built, e.g.
+	// for a locale the refresher doesn't fetch separately
+	if ix == nil || !sameList(ix.courses, courses) {
+		ix = Build(courses, locale)
+		s.store(key, ix)
+	}
+	return ix, nil
+}
+
+func (s *Service) load(key indexKey) *Index {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.indexes[key]
+	if !ok {
+		return nil
+	}
+	entry.usedAt = time.Now()
+	s.indexes[key] = entry
+	return entry.index
+}
+
+func (s *Service) store(key indexKey, ix *Index) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.indexes[key]; !ok && len(s.indexes) >= maxIndexes {
+		var oldest indexKey
+		var oldestAt time.Time
+		for k, entry := range s.indexes {
+			if oldestAt.IsZero() || entry.usedAt.Before(oldestAt) {
+				oldest, oldestAt = k, entry.usedAt
+			}
+		}
+		delete(s.indexes, oldest)
+	}
+	s.indexes[key] = indexEntry{index: ix, usedAt: time.Now()}
+}
+
+// sameList reports whether a and b are the same cached slice.
+func sameList(a, b []graph.CourseView) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
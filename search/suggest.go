@@ -0,0 +1,211 @@
+package search
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+)
+
+// Kind is what a suggestion completes to.
+type Kind string
+
+const (
+	KindCode    Kind = "code"
+	KindCourse  Kind = "course"
+	KindSubject Kind = "subject"
+)
+
+// kindBoost ranks an exact code above a name above a subject for equally
+// good matches, since codes are the most specific thing a learner types.
+var kindBoost = map[Kind]float64{KindCode: 3, KindCourse: 2, KindSubject: 1}
+
+// Suggestion is an autocomplete entry. Highlights are the byte ranges of Text
+// that matched the query.
+type Suggestion struct {
+	Kind       Kind
+	Text       string
+	CourseID   int
+	Score      float64
+	Highlights [][2]int
+}
+
+// Segment is a run of suggestion text, matched or not.
+type Segment struct {
+	Text  string
+	Match bool
+}
+
+// Segments splits the text at its highlights for rendering.
+func (s Suggestion) Segments() []Segment {
+	var segs []Segment
+	pos := 0
+	for _, h := range s.Highlights {
+		if h[0] > pos {
+			segs = append(segs, Segment{Text: s.Text[pos:h[0]]})
+		}
+		segs = append(segs, Segment{Text: s.Text[h[0]:h[1]], Match: true})
+		pos = h[1]
+	}
+	if pos < len(s.Text) {
+		segs = append(segs, Segment{Text: s.Text[pos:]})
+	}
+	return segs
+}
+
+// entry is a suggestible text with its words folded for matching.
+type entry struct {
+	kind     Kind
+	text     string
+	courseID int
+	words    []wordSpan
+}
+
+// wordSpan is a word of an entry: its folded form and where it sits in the
+// original text.
+type wordSpan struct {
+	folded     string
+	start, end int
+}
+
+func newEntry(kind Kind, text string, courseID int) entry {
+	e := entry{kind: kind, text: text, courseID: courseID}
+	start := -1
+	for i, r := range text + " " {
+		inWord := unicode.IsLetter(r) || unicode.IsNumber(r)
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			e.words = append(e.words, wordSpan{folded: fold(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	return e
+}
+
+// suggestionEntries lists what can be suggested for courses: every course
+// name and code, and each distinct subject name.
+func suggestionEntries(courses []graph.CourseView) []entry {
+	var entries []entry
+	subjects := map[string]bool{}
+	for _, c := range courses {
+		if c.CourseCode != "" {
+			entries = append(entries, newEntry(KindCode, c.CourseCode, c.ID))
+		}
+		if c.CourseName != "" {
+			entries = append(entries, newEntry(KindCourse, c.CourseName, c.ID))
+		}
+		for _, name := range Subjects(c) {
+			if key := fold(name); !subjects[key] {
+				subjects[key] = true
+				entries = append(entries, newEntry(KindSubject, name, 0))
+			}
+		}
+	}
+	return entries
+}
+
+// suggestCacheSize bounds the per-prefix cache; it is simply reset when full
+// since prefixes are cheap to recompute.
+const suggestCacheSize = 1024
+
+// Suggest returns up to limit entries whose words start with every word of
+// query, best first. Entries of courses keep rejects are skipped before the
+// limit is applied; a nil keep keeps them all. Results are cached per
+// normalised prefix for the life of the index.
+func (ix *Index) Suggest(query string, limit int, keep func(courseID int) bool) []Suggestion {
+	qwords := words(query)
+	if len(qwords) == 0 {
+		return nil
+	}
+	key := strings.Join(qwords, " ")
+
+	ix.mu.Lock()
+	cached, ok := ix.suggestions[key]
+	ix.mu.Unlock()
+	if !ok {
+		cached = ix.suggest(qwords)
+		ix.mu.Lock()
+		if len(ix.suggestions) >= suggestCacheSize {
+			clear(ix.suggestions)
+		}
+		ix.suggestions[key] = cached
+		ix.mu.Unlock()
+	}
+
+	if keep == nil {
+		return cached[:min(limit, len(cached))]
+	}
+	var kept []Suggestion
+	for _, s := range cached {
+		if len(kept) == limit {
+			break
+		}
+		if keep(s.CourseID) {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+func (ix *Index) suggest(qwords []string) []Suggestion {
+	var results []Suggestion
+	for _, e := range ix.entries {
+		s, ok := e.match(qwords)
+		if ok {
+			results = append(results, s)
+		}
+	}
+	slices.SortFunc(results, func(a, b Suggestion) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Text, b.Text)
+	})
+	return results
+}
+
+// match checks that each query word is the prefix of a distinct word of the
+// entry, and scores the entry: matches at the start of the text and whole
+// words count more, and shorter texts beat longer ones.
+func (e entry) match(qwords []string) (Suggestion, bool) {
+	s := Suggestion{Kind: e.kind, Text: e.text, CourseID: e.courseID, Score: kindBoost[e.kind]}
+	used := make([]bool, len(e.words))
+	for qi, q := range qwords {
+		found := false
+		for wi, w := range e.words {
+			if used[wi] || !strings.HasPrefix(w.folded, q) {
+				continue
+			}
+			used[wi], found = true, true
+			s.Highlights = append(s.Highlights, [2]int{w.start, w.start + originalLen(e.text[w.start:w.end], len(q))})
+			s.Score += float64(len(q)) / float64(len(w.folded))
+			if wi == qi {
+				s.Score += 1
+			}
+			break
+		}
+		if !found {
+			return Suggestion{}, false
+		}
+	}
+	s.Score -= float64(len(e.words)) * 0.05
+	slices.SortFunc(s.Highlights, func(a, b [2]int) int { return cmp.Compare(a[0], b[0]) })
+	return s, true
+}
+
+// originalLen maps a prefix of n bytes of the folded word back to a byte
+// length in the original word, folding a rune at a time.
+func originalLen(word string, n int) int {
+	folded := 0
+	for i, r := range word {
+		if folded >= n {
+			return i
+		}
+		folded += len(fold(string(r)))
+	}
+	return len(word)
+}
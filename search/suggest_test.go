@@ -0,0 +1,113 @@
+package search
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+	"github.com/Tonnie-Exelero/go-ms-kit/models"
+
+	"golang.org/x/text/language"
+)
+
+func course(id int, code, name string) graph.CourseView {
+	return graph.CourseView{Course: models.Course{ID: id, CourseCode: code, CourseName: name}}
+}
+
+func suggestionTexts(suggestions []Suggestion) []string {
+	var texts []string
+	for _, s := range suggestions {
+		texts = append(texts, s.Text)
+	}
+	return texts
+}
+
+func TestSuggestKeep(t *testing.T) {
+	ix := Build([]graph.CourseView{
+		course(1, "", "Diploma of Leadership"),
+		course(2, "", "Diploma of Nursing"),
+		course(3, "", "Diploma of Marketing"),
+		course(4, "", "Diploma of Project Management"),
+	}, language.English)
+	all := []string{"Diploma of Leadership", "Diploma of Marketing", "Diploma of Nursing", "Diploma of Project Management"}
+
+	tests := []struct {
+		name  string
+		limit int
+		keep  func(int) bool
+		want  []string
+	}{
+		{name: "nil keeps all", limit: 10, want: all},
+		{name: "nil with limit", limit: 2, want: all[:2]},
+		{
+			name:  "filtered before the limit",
+			limit: 2,
+			keep:  func(id int) bool { return id != 1 && id != 3 },
+			want:  []string{"Diploma of Nursing", "Diploma of Project Management"},
+		},
+		{name: "keep none", limit: 2, keep: func(int) bool { return false }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := suggestionTexts(ix.Suggest("dip", tt.limit, tt.keep))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Suggest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Filtering must not touch the cached suggestions
+	if got := suggestionTexts(ix.Suggest("dip", 10, nil)); !slices.Equal(got, all) {
+		t.Errorf("Suggest() after filtering = %q, want %q", got, all)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	leadership := course(1, "DIP50120", "Diploma of Leadership and Management")
+	leadership.Modules = []models.Module{{Units: []models.Unit{{Name: "Lead a team"}}}}
+	nursing := course(2, "", "Diploma of Nursing")
+	nursing.Modules = []models.Module{{Units: []models.Unit{{Name: "lead a Team"}}}}
+	ix := Build([]graph.CourseView{leadership, nursing, course(3, "", "Gestión de proyectos")}, language.English)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "code above names", query: "dip", want: []string{"DIP50120", "Diploma of Nursing", "Diploma of Leadership and Management"}},
+		{name: "every word starts a word", query: "dip lead", want: []string{"Diploma of Leadership and Management"}},
+		{name: "match at the start first", query: "lead", want: []string{"Lead a team", "Diploma of Leadership and Management"}},
+		{name: "query words need distinct words", query: "lead lead"},
+		{name: "diacritics folded", query: "gestion", want: []string{"Gestión de proyectos"}},
+		{name: "no words", query: " - "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suggestionTexts(ix.Suggest(tt.query, 10, nil)); !slices.Equal(got, tt.want) {
+				t.Errorf("Suggest(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestionSegments(t *testing.T) {
+	ix := Build([]graph.CourseView{course(1, "", "Gestión de proyectos")}, language.English)
+
+	tests := []struct {
+		query string
+		want  []Segment
+	}{
+		{"gestio", []Segment{{Text: "Gestió", Match: true}, {Text: "n de proyectos"}}},
+		{"pro ge", []Segment{{Text: "Ge", Match: true}, {Text: "stión de "}, {Text: "pro", Match: true}, {Text: "yectos"}}},
+		{"gestión de proyectos", []Segment{{Text: "Gestión", Match: true}, {Text: " "}, {Text: "de", Match: true}, {Text: " "}, {Text: "proyectos", Match: true}}},
+	}
+	for _, tt := range tests {
+		suggestions := ix.Suggest(tt.query, 1, nil)
+		if len(suggestions) != 1 {
+			t.Fatalf("Suggest(%q) = %d suggestions, want 1", tt.query, len(suggestions))
+		}
+		if got := suggestions[0].Segments(); !slices.Equal(got, tt.want) {
+			t.Errorf("Suggest(%q) segments = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Package tenant resolves which tenant a request belongs to and carries it
+// through the request context to handlers, graph calls and templates.
+package tenant
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/config"
+	"github.com/Tonnie-Exelero/go-ms-kit/graph"
+)
+
+// Headers the embed and API clients use to identify themselves.
+const (
+	APIKeyHeader  = "X-API-Key"
+	PartnerHeader = "X-MF-Partner"
+)
+
+// Tenant is a resolved tenant with its GraphQL client.
+type Tenant struct {
+	config.Tenant
+	Graph *graph.Client
+}
+
+// AllowsOrigin reports whether a browser on origin may call the service on
+// behalf of this tenant.
+func (t *Tenant) AllowsOrigin(origin string) bool {
+	return slices.Contains(t.AllowedOrigins, "*") || slices.Contains(t.AllowedOrigins, origin)
+}
+
+// Registry holds every tenant and resolves requests to them.
+type Registry struct {
+	def     *Tenant
+	tenants []*Tenant
+	clients []*graph.Client
+}
+
+// NewRegistry builds a GraphQL client per distinct backend so tenants that
+// share a backend also share its cache.
+func NewRegistry(cfg *config.Config) *Registry {
+	r := &Registry{}
+	byEndpoint := map[config.Backend]*graph.Client{}
+
+	client := func(b config.Backend, ttl time.Duration) *graph.Client {
+		if c, ok := byEndpoint[b]; ok {
+			return c
+		}
+		c := graph.NewClient(b, ttl)
+		byEndpoint[b] = c
+		r.clients = append(r.clients, c)
+		return c
+	}
+
+	r.def = &Tenant{Tenant: cfg.DefaultTenant(), Graph: client(cfg.Backend, cfg.CacheTTL)}
+	for _, t := range cfg.Tenants {
+		r.tenants = append(r.tenants, &Tenant{Tenant: t, Graph: client(t.Backend, cfg.CacheTTL)})
+	}
+	return r
+}
+
+// Default returns the tenant used when nothing else matches.
+func (r *Registry) Default() *Tenant {
+	return r.def
+}
+
+// All returns the default tenant followed by every configured tenant.
+func (r *Registry) All() []*Tenant {
+	return append([]*Tenant{r.def}, r.tenants...)
+}
+
+// Clients returns one GraphQL client per distinct backend.
+func (r *Registry) Clients() []*graph.Client {
+	return r.clients
+}
+
+// Resolve picks the tenant for a request and returns the path with any
+// tenant prefix removed. Explicit credentials win over routing hints.
+func (r *Registry) Resolve(req *http.Request) (*Tenant, string) {
+	path := req.URL.Path
+
+	if key := req.Header.Get(APIKeyHeader); key != "" {
+		for _, t := range r.tenants {
+			for _, k := range t.APIKeys {
+				if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+					return t, stripPrefix(path, t.PathPrefix)
+				}
+			}
+		}
+	}
+
+	partnerKey := req.Header.Get(PartnerHeader)
+	if partnerKey == "" {
+		partnerKey = req.URL.Query().Get("partner")
+	}
+	if partnerKey != "" {
+		for _, t := range r.tenants {
+			if slices.Contains(t.PartnerKeys, partnerKey) {
+				return t, stripPrefix(path, t.PathPrefix)
+			}
+		}
+	}
+
+	for _, t := range r.tenants {
+		if underPrefix(path, t.PathPrefix) {
+			return t, stripPrefix(path, t.PathPrefix)
+		}
+	}
+
+	host := req.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	for _, t := range r.tenants {
+		for _, h := range t.Hosts {
+			if strings.EqualFold(h, host) {
+				return t, path
+			}
+		}
+	}
+
+	return r.def, path
+}
+
+// underPrefix reports whether path is prefix or below it, so /acme matches
+// /acme/courses but not /acmefoo.
+func underPrefix(path, prefix string) bool {
+	return prefix != "" && (path == prefix || strings.HasPrefix(path, prefix+"/"))
+}
+
+func stripPrefix(path, prefix string) string {
+	if !underPrefix(path, prefix) {
+		return path
+	}
+	if rest := strings.TrimPrefix(path, prefix); rest != "" {
+		return rest
+	}
+	return "/"
+}
+
+type contextKey struct{}
+
+// WithTenant returns a copy of ctx carrying t.
+func WithTenant(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the request's tenant, or nil outside a request.
+func FromContext(ctx context.Context) *Tenant {
+	t, _ := ctx.Value(contextKey{}).(*Tenant)
+	return t
+}
+
+type partnerKey struct{}
+
+// WithPartner returns a copy of ctx carrying the partner whose embed made
+// the request.
+func WithPartner(ctx context.Context, p config.Partner) context.Context {
+	return context.WithValue(ctx, partnerKey{}, p)
+}
+
+// PartnerFromContext returns the partner whose embed made the request, if
+// any.
+func PartnerFromContext(ctx context.Context) (config.Partner, bool) {
+	p, ok := ctx.Value(partnerKey{}).(config.Partner)
+	return p, ok
+}
+
+// Allows reports whether the request's partner has the feature switched on.
+// Requests that don't come from a known partner's embed get every feature.
+func Allows(ctx context.Context, feature string) bool {
+	p, ok := PartnerFromContext(ctx)
+	return !ok || p.HasFeature(feature)
+}
+
+// Path prefixes an absolute service path with the tenant's path prefix so
+// links rendered for a prefixed tenant stay on that tenant.
+func Path(ctx context.Context, p string) string {
+	if t := FromContext(ctx); t != nil {
+		return t.PathPrefix + p
+	}
+	return p
+}
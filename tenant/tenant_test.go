@@ -0,0 +1,138 @@
+package tenant
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/config"
+)
+
+func TestResolve(t *testing.T) {
+	backend := config.Backend{GraphQLURL: "https://backend.example.com/graphql", AnonKey: "anon", APIKey: "key"}
+	reg := NewRegistry(&config.Config{
+		Backend: backend,
+		Tenants: []config.Tenant{
+			{ID: "acme", PathPrefix: "/acme", APIKeys: []config.Secret{"acme-key"}, Backend: backend},
+			{ID: "globex", Hosts: []string{"courses.globex.example"}, PartnerKeys: []string{"globex-partner"}, Backend: backend},
+			{ID: "initech", PathPrefix: "/initech", Hosts: []string{"initech.example"}, Backend: backend},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		target   string
+		host     string
+		headers  map[string]string
+		wantID   string
+		wantPath string
+	}{
+		{name: "default", target: "/courses", wantID: config.DefaultTenantID, wantPath: "/courses"},
+		{name: "path prefix", target: "/acme/courses/1", wantID: "acme", wantPath: "/courses/1"},
+		{name: "path prefix alone", target: "/acme", wantID: "acme", wantPath: "/"},
+		{name: "path prefix with slash", target: "/acme/", wantID: "acme", wantPath: "/"},
+		{name: "path sharing the prefix's start", target: "/acmefoo", wantID: config.DefaultTenantID, wantPath: "/acmefoo"},
+		{name: "host", target: "/courses", host: "courses.globex.example", wantID: "globex", wantPath: "/courses"},
+		{name: "host with port and case", target: "/courses", host: "Courses.Globex.Example:8080", wantID: "globex", wantPath: "/courses"},
+		{name: "path prefix over host", target: "/acme/courses", host: "initech.example", wantID: "acme", wantPath: "/courses"},
+		{name: "host keeps unprefixed path", target: "/courses", host: "initech.example", wantID: "initech", wantPath: "/courses"},
+		{
+			name:     "API key",
+			target:   "/courses",
+			headers:  map[string]string{APIKeyHeader: "acme-key"},
+			wantID:   "acme",
+			wantPath: "/courses",
+		},
+		{
+			name:     "API key strips its prefix",
+			target:   "/acme/courses",
+			headers:  map[string]string{APIKeyHeader: "acme-key"},
+			wantID:   "acme",
+			wantPath: "/courses",
+		},
+		{
+			name:     "API key leaves a lookalike prefix",
+			target:   "/acmefoo",
+			headers:  map[string]string{APIKeyHeader: "acme-key"},
+			wantID:   "acme",
+			wantPath: "/acmefoo",
+		},
+		{
+			name:     "API key over path prefix and host",
+			target:   "/initech/courses",
+			host:     "courses.globex.example",
+			headers:  map[string]string{APIKeyHeader: "acme-key"},
+			wantID:   "acme",
+			wantPath: "/initech/courses",
+		},
+		{
+			name:     "unknown API key falls through",
+			target:   "/initech/courses",
+			headers:  map[string]string{APIKeyHeader: "nope"},
+			wantID:   "initech",
+			wantPath: "/courses",
+		},
+		{
+			name:     "partner header",
+			target:   "/courses",
+			headers:  map[string]string{PartnerHeader: "globex-partner"},
+			wantID:   "globex",
+			wantPath: "/courses",
+		},
+		{name: "partner query", target: "/courses?partner=globex-partner", wantID: "globex", wantPath: "/courses"},
+		{
+			name:     "API key over partner",
+			target:   "/courses",
+			headers:  map[string]string{APIKeyHeader: "acme-key", PartnerHeader: "globex-partner"},
+			wantID:   "acme",
+			wantPath: "/courses",
+		},
+		{
+			name:     "partner over path prefix",
+			target:   "/initech/courses",
+			headers:  map[string]string{PartnerHeader: "globex-partner"},
+			wantID:   "globex",
+			wantPath: "/initech/courses",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.target, nil)
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			got, path := reg.Resolve(req)
+			if got.ID != tt.wantID || path != tt.wantPath {
+				t.Errorf("Resolve() = %q, %q, want %q, %q", got.ID, path, tt.wantID, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestRegistrySharesClients(t *testing.T) {
+	shared := config.Backend{GraphQLURL: "https://shared.example.com/graphql", AnonKey: "anon", APIKey: "key"}
+	own := config.Backend{GraphQLURL: "https://own.example.com/graphql", AnonKey: "anon", APIKey: "key"}
+	reg := NewRegistry(&config.Config{
+		Backend: shared,
+		Tenants: []config.Tenant{
+			{ID: "acme", PathPrefix: "/acme", Backend: shared},
+			{ID: "globex", PathPrefix: "/globex", Backend: own},
+		},
+	})
+
+	all := reg.All()
+	if len(all) != 3 || all[0] != reg.Default() {
+		t.Fatalf("All() = %d tenants, want the default and 2 others", len(all))
+	}
+	if all[0].Graph != all[1].Graph {
+		t.Error("tenants on the same backend got different clients")
+	}
+	if all[0].Graph == all[2].Graph {
+		t.Error("tenants on different backends share a client")
+	}
+	if n := len(reg.Clients()); n != 2 {
+		t.Errorf("Clients() = %d, want 2", n)
+	}
+}
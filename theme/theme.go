@@ -0,0 +1,165 @@
+// Package theme turns tenant, brand and partner theme tokens into CSS custom
+// properties and picks safe logo URLs for templates.
+package theme
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Tonnie-Exelero/go-ms-kit/config"
+	"github.com/Tonnie-Exelero/go-ms-kit/tenant"
+)
+
+// Theme is the set of overridable design tokens. Empty fields keep the
+// stylesheet defaults.
+type Theme struct {
+	Logo       string
+	Primary    string
+	Accent     string
+	Text       string
+	Background string
+	Header     string
+	FontFamily string
+	Radius     string
+}
+
+// token describes how a config token maps onto a CSS custom property.
+type token struct {
+	name   string // key in the tenant/partner theme map
+	cssVar string
+	valid  *regexp.Regexp
+	field  func(*Theme) *string
+}
+
+var (
+	colorPattern  = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|(rgb|rgba|hsl|hsla)\([0-9.,%\s/]+\)|[a-zA-Z]{3,20})$`)
+	fontPattern   = regexp.MustCompile(`^[a-zA-Z0-9 ,'"-]{1,200}$`)
+	lengthPattern = regexp.MustCompile(`^[0-9.]{1,6}(px|rem|em|%)?$`)
+)
+
+var tokens = []token{
+	{"primary", "--mf-color-primary", colorPattern, func(t *Theme) *string { return &t.Primary }},
+	{"accent", "--mf-color-accent", colorPattern, func(t *Theme) *string { return &t.Accent }},
+	{"text", "--mf-color-text", colorPattern, func(t *Theme) *string { return &t.Text }},
+	{"background", "--mf-color-background", colorPattern, func(t *Theme) *string { return &t.Background }},
+	{"header", "--mf-color-header", colorPattern, func(t *Theme) *string { return &t.Header }},
+	{"font_family", "--mf-font-family", fontPattern, func(t *Theme) *string { return &t.FontFamily }},
+	{"radius", "--mf-radius", lengthPattern, func(t *Theme) *string { return &t.Radius }},
+}
+
+// FromTokens builds a theme from a tenant or partner theme map. Values that
+// aren't valid for their token are dropped rather than passed into CSS.
+func FromTokens(m map[string]string) Theme {
+	var t Theme
+	for _, tok := range tokens {
+		if v := strings.TrimSpace(m[tok.name]); v != "" && tok.valid.MatchString(v) {
+			*tok.field(&t) = v
+		}
+	}
+	t.Logo = safeURL(m["logo"])
+	return t
+}
+
+// Merge returns t with every non-empty field of o applied on top.
+func (t Theme) Merge(o Theme) Theme {
+	for _, tok := range tokens {
+		if v := *tok.field(&o); v != "" {
+			*tok.field(&t) = v
+		}
+	}
+	if o.Logo != "" {
+		t.Logo = o.Logo
+	}
+	return t
+}
+
+// Vars returns the CSS custom properties the theme sets.
+func (t Theme) Vars() map[string]string {
+	vars := map[string]string{}
+	for _, tok := range tokens {
+		if v := *tok.field(&t); v != "" {
+			vars[tok.cssVar] = v
+		}
+	}
+	return vars
+}
+
+// CSS renders the custom properties as a rule for selector, or "" when the
+// theme doesn't override anything.
+func (t Theme) CSS(selector string) string {
+	vars := t.Vars()
+	if len(vars) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(selector + "{")
+	for _, name := range names {
+		b.WriteString(name + ":" + vars[name] + ";")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// ForPartner layers a partner's theme over its tenant's.
+func ForPartner(ctx context.Context, p config.Partner) Theme {
+	return FromContext(ctx).Merge(FromTokens(p.Theme))
+}
+
+// ForBrand returns the tokens a course's brand sets, with the request's
+// partner tokens layered on top so the partner still has the last word. It
+// is empty when the brand sets none.
+func ForBrand(ctx context.Context, tokens map[string]string) Theme {
+	t := FromTokens(tokens)
+	if len(t.Vars()) == 0 {
+		return Theme{}
+	}
+	if p, ok := tenant.PartnerFromContext(ctx); ok {
+		t = t.Merge(FromTokens(p.Theme))
+	}
+	t.Logo = ""
+	return t
+}
+
+// FromContext returns the theme of the request's tenant.
+func FromContext(ctx context.Context) Theme {
+	if t := tenant.FromContext(ctx); t != nil {
+		return FromTokens(t.Theme)
+	}
+	return Theme{}
+}
+
+// Logo returns the first usable logo URL, or fallback when none is.
+func Logo(fallback string, candidates ...string) string {
+	for _, c := range candidates {
+		if u := safeURL(c); u != "" {
+			return u
+		}
+	}
+	return fallback
+}
+
+// safeURL accepts absolute http(s) URLs and site-relative paths only, so a
+// bad record can't inject javascript: or data: URLs into an img src.
+func safeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	if strings.HasPrefix(raw, "/") && !strings.HasPrefix(raw, "//") {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return u.String()
+}